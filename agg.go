@@ -1,6 +1,10 @@
 package dt
 
-import "math"
+import (
+	"math"
+	"strings"
+	"time"
+)
 
 // First returns the first of list l.
 func First(l List) Value {
@@ -24,40 +28,49 @@ func Count(l List) Value {
 }
 
 // Sum returns the sum of list l.
+// The sum of Int values is an Int, and the sum of Int and Decimal values is a Decimal,
+// unless it overflows.
+// Missing values are skipped, but NaN values are not, so the sum is NaN if any value is NaN.
 func Sum(l List) Value {
+	if isExact(l) {
+		if v, ok := exactSum(l); ok {
+			return v
+		}
+	}
 	s := 0.0
 	for _, v := range l {
-		if v == nil {
-			return Number(math.NaN())
+		if !IsNull(v) {
+			s += v.Number()
 		}
-		s += v.Number()
 	}
 	return Number(s)
 }
 
 // Mean returns the mean of list l.
+// Missing values are skipped as in Sum, and the mean of no values is NaN.
 func Mean(l List) Value {
-	return Number(Sum(l).Number()) / Number(len(l))
+	return Number(Sum(l).Number()) / Number(count(l))
 }
 
 // Var returns the var of list l.
+// Missing values are skipped as in Sum, and the var of no values is NaN.
 func Var(l List) Value {
-	x, y, n := 0.0, 0.0, float64(len(l))
+	x, y, n := 0.0, 0.0, float64(count(l))
 	for _, v := range l {
-		if v == nil {
-			return Number(math.NaN())
+		if !IsNull(v) {
+			z := v.Number()
+			x += z * z
+			y += z
 		}
-		z := v.Number()
-		x += z * z
-		y += z
 	}
 	y /= n
 	return Number(x/n - y*y)
 }
 
 // Std returns the std of list l.
+// Missing values are skipped as in Sum, and the std of no values is NaN.
 func Std(l List) Value {
-	if v := Var(l).Number(); v > 0 {
+	if v := Var(l).Number(); v > 0 || math.IsNaN(v) {
 		return Number(math.Sqrt(v))
 	}
 	return Number(0)
@@ -65,9 +78,18 @@ func Std(l List) Value {
 
 // Min returns the min of list l.
 func Min(l List) Value {
+	if isTimes(l) {
+		return timeExtreme(l, time.Time.Before)
+	}
+	if isExact(l) {
+		return exactExtreme(l, -1)
+	}
+	if dict, ok := categories(l); ok && dict.ordered {
+		return categoryExtreme(l, -1)
+	}
 	m := math.Inf(1)
 	for _, v := range l {
-		if v != nil {
+		if !IsNull(v) {
 			if x := v.Number(); x < m {
 				m = x
 			}
@@ -78,9 +100,18 @@ func Min(l List) Value {
 
 // Max returns the max of list l.
 func Max(l List) Value {
+	if isTimes(l) {
+		return timeExtreme(l, time.Time.After)
+	}
+	if isExact(l) {
+		return exactExtreme(l, 1)
+	}
+	if dict, ok := categories(l); ok && dict.ordered {
+		return categoryExtreme(l, 1)
+	}
 	m := math.Inf(-1)
 	for _, v := range l {
-		if v != nil {
+		if !IsNull(v) {
 			if x := v.Number(); x > m {
 				m = x
 			}
@@ -88,3 +119,119 @@ func Max(l List) Value {
 	}
 	return Number(m)
 }
+
+// StringJoin returns the aggregate function,
+// which joins the strings of the values by sep, and skips NA values.
+// It returns nil if all values are NA.
+func StringJoin(sep string) func(List) Value {
+	return func(l List) Value {
+		var ss []string
+		for _, v := range l {
+			if !IsNA(v) {
+				ss = append(ss, v.String())
+			}
+		}
+		if ss == nil {
+			return nil
+		}
+		return String(strings.Join(ss, sep))
+	}
+}
+
+// count returns the number of values which are not missing.
+func count(l List) int {
+	n := 0
+	for _, v := range l {
+		if !IsNull(v) {
+			n++
+		}
+	}
+	return n
+}
+
+func isTimes(l List) bool {
+	ok := false
+	for _, v := range l {
+		if IsNA(v) {
+			continue
+		}
+		if _, ok = v.(Time); !ok {
+			return false
+		}
+	}
+	return ok
+}
+
+func timeExtreme(l List, f func(time.Time, time.Time) bool) Value {
+	var m Value
+	for _, v := range l {
+		if t, ok := v.(Time); ok {
+			if m == nil || f(time.Time(t), time.Time(m.(Time))) {
+				m = t
+			}
+		}
+	}
+	return m
+}
+
+func isExact(l List) bool {
+	ok := false
+	for _, v := range l {
+		if IsNA(v) {
+			continue
+		}
+		if _, ok = toDecimal(v); !ok {
+			return false
+		}
+	}
+	return ok
+}
+
+func exactSum(l List) (Value, bool) {
+	ints := true
+	s := Decimal{}
+	for _, v := range l {
+		if IsNull(v) {
+			continue
+		}
+		x, ok := toDecimal(v)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := v.(Int); !ok {
+			ints = false
+		}
+		if s, ok = s.add(x); !ok {
+			return nil, false
+		}
+	}
+	if ints {
+		return Int(s.coef), true
+	}
+	return s, true
+}
+
+func exactExtreme(l List, sign int) Value {
+	var m Value
+	var d Decimal
+	for _, v := range l {
+		if x, ok := toDecimal(v); ok {
+			if m == nil || x.Cmp(d) == sign {
+				m, d = v, x
+			}
+		}
+	}
+	return m
+}
+
+func categoryExtreme(l List, sign int) Value {
+	var m *Category
+	for _, v := range l {
+		if c, ok := v.(*Category); ok {
+			if m == nil || Compare(c, m) == sign {
+				m = c
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,84 @@
+package dt_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+)
+
+func TestTimeAggregates(t *testing.T) {
+	day := func(d int) dt.Value {
+		return dt.Time(time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC))
+	}
+	l := dt.List{day(3), nil, day(1), dt.Null{}, day(2)}
+	tests := []struct {
+		name string
+		f    func(dt.List) dt.Value
+		want dt.Value
+	}{
+		{"First", dt.First, day(3)},
+		{"Last", dt.Last, day(2)},
+		{"Min", dt.Min, day(1)},
+		{"Max", dt.Max, day(3)},
+	}
+	for _, tt := range tests {
+		if got := tt.f(l); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMissingAggregates(t *testing.T) {
+	nan := dt.Number(math.NaN())
+	tests := []struct {
+		name string
+		f    func(dt.List) dt.Value
+		l    dt.List
+		want dt.Value
+	}{
+		{"Sum", dt.Sum, dt.List{dt.Number(1), nil, dt.Number(2), dt.Null{}}, dt.Number(3)},
+		{"Sum", dt.Sum, dt.List{dt.Int(1), nil, dt.Int(2)}, dt.Int(3)},
+		{"Sum", dt.Sum, dt.List{dt.Number(1), nil, nan}, nan},
+		{"Sum", dt.Sum, dt.List{nil}, dt.Number(0)},
+		{"Mean", dt.Mean, dt.List{dt.Number(1), nil, dt.Number(2)}, dt.Number(1.5)},
+		{"Mean", dt.Mean, dt.List{nil, dt.Null{}}, nan},
+		{"Var", dt.Var, dt.List{dt.Number(1), dt.Null{}, dt.Number(3)}, dt.Number(1)},
+		{"Std", dt.Std, dt.List{dt.Number(1), nil, dt.Number(3)}, dt.Number(1)},
+		{"Std", dt.Std, dt.List{nil}, nan},
+	}
+	for _, tt := range tests {
+		if got := tt.f(tt.l); !same(got, tt.want) {
+			t.Errorf("%s(%v) = %#v, want %#v", tt.name, tt.l, got, tt.want)
+		}
+	}
+}
+
+func TestIsNA(t *testing.T) {
+	tests := []struct {
+		v                 dt.Value
+		na, isNull, isNaN bool
+	}{
+		{nil, true, true, false},
+		{dt.Null{}, true, true, false},
+		{dt.Number(math.NaN()), true, false, true},
+		{dt.Number(0), false, false, false},
+		{dt.String(""), false, false, false},
+	}
+	for _, tt := range tests {
+		if na, isNull, isNaN := dt.IsNA(tt.v), dt.IsNull(tt.v), dt.IsNaN(tt.v); na != tt.na || isNull != tt.isNull || isNaN != tt.isNaN {
+			t.Errorf("%#v: got %v, %v, %v, want %v, %v, %v", tt.v, na, isNull, isNaN, tt.na, tt.isNull, tt.isNaN)
+		}
+	}
+}
+
+func TestStringJoin(t *testing.T) {
+	join := dt.StringJoin(";")
+	if got := join(dt.List{dt.String("a"), nil, dt.Int(1), dt.Null{}, dt.String("")}); got != dt.String("a;1;") {
+		t.Errorf("got %#v", got)
+	}
+	if got := join(dt.List{nil, dt.Null{}}); got != nil {
+		t.Errorf("got %#v, want nil", got)
+	}
+}
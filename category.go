@@ -0,0 +1,198 @@
+package dt
+
+// Categories is a dictionary of categories, which stores each distinct string once.
+// It is not safe for concurrent use when categories are added.
+type Categories struct {
+	ordered bool
+	levels  []*Category
+	index   map[string]*Category
+}
+
+// Category is a categorical value, which is a string with a code in its dictionary.
+// Categories of the same ordered dictionary are compared by their codes,
+// otherwise categories are compared as strings.
+type Category struct {
+	dict  *Categories
+	code  int
+	value string
+}
+
+// NewCategories creates a dictionary of the levels in order.
+// The duplicate levels are ignored.
+func NewCategories(ordered bool, levels ...string) *Categories {
+	a := &Categories{
+		ordered: ordered,
+		index:   make(map[string]*Category, len(levels)),
+	}
+	for _, s := range levels {
+		a.Add(s)
+	}
+	return a
+}
+
+// Ordered checks if the categories are ordered.
+func (a *Categories) Ordered() bool {
+	return a.ordered
+}
+
+// Len returns the number of categories.
+func (a *Categories) Len() int {
+	return len(a.levels)
+}
+
+// Levels returns the strings of the categories in order of their codes.
+func (a *Categories) Levels() []string {
+	levels := make([]string, len(a.levels))
+	for i, c := range a.levels {
+		levels[i] = c.value
+	}
+	return levels
+}
+
+// Code returns the category of the code.
+// It panics if the code is out of range.
+func (a *Categories) Code(code int) *Category {
+	c, err := a.TryCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// TryCode returns the category of the code, or returns an error if the code is out of range.
+func (a *Categories) TryCode(code int) (*Category, error) {
+	if code < 0 || code >= len(a.levels) {
+		return nil, &IndexError{
+			Index: code,
+			Len:   len(a.levels),
+		}
+	}
+	return a.levels[code], nil
+}
+
+// Get returns the category of string s, or nil if it is not found.
+func (a *Categories) Get(s string) *Category {
+	return a.index[s]
+}
+
+// Add returns the category of string s, which is added as the last one if it is not found.
+func (a *Categories) Add(s string) *Category {
+	if c, ok := a.index[s]; ok {
+		return c
+	}
+	c := &Category{
+		dict:  a,
+		code:  len(a.levels),
+		value: s,
+	}
+	a.levels = append(a.levels, c)
+	a.index[s] = c
+	return c
+}
+
+// Encode encodes the values of the list to categories by their strings, and keeps the null values.
+// The values not found are added to unordered categories, and become nil for ordered ones.
+func (a *Categories) Encode(list List) List {
+	b := make(List, len(list))
+	for i, v := range list {
+		switch x := v.(type) {
+		case *Category:
+			if x.dict == a {
+				b[i] = x
+				continue
+			}
+		case nil, Null:
+			b[i] = v
+			continue
+		}
+		if a.ordered {
+			if c := a.Get(v.String()); c != nil {
+				b[i] = c
+			}
+		} else {
+			b[i] = a.Add(v.String())
+		}
+	}
+	return b
+}
+
+// Categories returns the dictionary of category a.
+func (a *Category) Categories() *Categories {
+	return a.dict
+}
+
+// Code returns the code of category a.
+func (a *Category) Code() int {
+	return a.code
+}
+
+// String returns as a string value.
+func (a *Category) String() string {
+	return a.value
+}
+
+// Number returns as a number value.
+func (a *Category) Number() float64 {
+	return String(a.value).Number()
+}
+
+// AsCategorical encodes the key list to categories in order of their first occurrences.
+// It panics if the key is not found.
+func (a *Frame) AsCategorical(key string) *Frame {
+	if _, err := a.TryAsCategorical(key); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryAsCategorical encodes the key list to categories in order of their first occurrences,
+// or returns an error if the key is not found.
+func (a *Frame) TryAsCategorical(key string) (*Frame, error) {
+	return a.encode(key, NewCategories(false))
+}
+
+// AsOrdered encodes the key list to ordered categories of the levels,
+// and the values not in the levels become nil.
+// It panics if the key is not found.
+func (a *Frame) AsOrdered(key string, levels ...string) *Frame {
+	if _, err := a.TryAsOrdered(key, levels...); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryAsOrdered encodes the key list to ordered categories of the levels,
+// or returns an error if the key is not found.
+func (a *Frame) TryAsOrdered(key string, levels ...string) (*Frame, error) {
+	return a.encode(key, NewCategories(true, levels...))
+}
+
+func (a *Frame) encode(key string, dict *Categories) (*Frame, error) {
+	j, ok := a.index[key]
+	if !ok {
+		return nil, keyNotFound(key)
+	}
+	a.lists[j] = dict.Encode(a.lists[j])
+	a.shared[j] = false
+	return a, nil
+}
+
+// categories returns the dictionary of the list,
+// if all the non-null values are categories of the same dictionary.
+func categories(list List) (*Categories, bool) {
+	var dict *Categories
+	for _, v := range list {
+		switch x := v.(type) {
+		case *Category:
+			if dict == nil {
+				dict = x.dict
+			} else if dict != x.dict {
+				return nil, false
+			}
+		case nil, Null:
+		default:
+			return nil, false
+		}
+	}
+	return dict, dict != nil
+}
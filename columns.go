@@ -0,0 +1,154 @@
+package dt
+
+import (
+	"sort"
+)
+
+// Reorder moves the key lists to the front in the given order,
+// and keeps the order of the others after them.
+// It panics if any key is not found or duplicate.
+func (a *Frame) Reorder(keys ...string) *Frame {
+	if _, err := a.TryReorder(keys...); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryReorder moves the key lists to the front in the given order,
+// and keeps the order of the others after them,
+// or returns an error if any key is not found or duplicate.
+func (a *Frame) TryReorder(keys ...string) (*Frame, error) {
+	if err := a.Check(keys...); err != nil {
+		return nil, err
+	}
+	moved := make([]bool, len(a.lists))
+	order := make([]int, 0, len(a.lists))
+	for _, key := range keys {
+		j := a.index[key]
+		if moved[j] {
+			return nil, keyExists(key)
+		}
+		moved[j] = true
+		order = append(order, j)
+	}
+	for j := range a.lists {
+		if !moved[j] {
+			order = append(order, j)
+		}
+	}
+	a.permute(order)
+	return a, nil
+}
+
+// Insert inserts the key list at the position, which is in [0, Len of keys].
+// It panics if the key already exists, the list length is invalid or the position is out of range.
+func (a *Frame) Insert(pos int, key string, list List) *Frame {
+	if _, err := a.TryInsert(pos, key, list); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryInsert inserts the key list at the position, which is in [0, Len of keys],
+// or returns an error if the key already exists, the list length is invalid or the position is out of range.
+func (a *Frame) TryInsert(pos int, key string, list List) (*Frame, error) {
+	n := len(a.lists)
+	if pos < 0 || pos > n {
+		return nil, &IndexError{
+			Index: pos,
+			Len:   n,
+		}
+	}
+	if _, err := a.TryAdd(key, list); err != nil {
+		return nil, err
+	}
+	order := make([]int, 0, n+1)
+	for j := 0; j < pos; j++ {
+		order = append(order, j)
+	}
+	order = append(order, n)
+	for j := pos; j < n; j++ {
+		order = append(order, j)
+	}
+	a.permute(order)
+	return a, nil
+}
+
+// MoveBefore moves the key list before the target list.
+// It panics if any key is not found.
+func (a *Frame) MoveBefore(key, target string) *Frame {
+	if _, err := a.TryMoveBefore(key, target); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryMoveBefore moves the key list before the target list, or returns an error if any key is not found.
+func (a *Frame) TryMoveBefore(key, target string) (*Frame, error) {
+	return a.move(key, target, 0)
+}
+
+// MoveAfter moves the key list after the target list.
+// It panics if any key is not found.
+func (a *Frame) MoveAfter(key, target string) *Frame {
+	if _, err := a.TryMoveAfter(key, target); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryMoveAfter moves the key list after the target list, or returns an error if any key is not found.
+func (a *Frame) TryMoveAfter(key, target string) (*Frame, error) {
+	return a.move(key, target, 1)
+}
+
+// SortColumns sorts the lists by their keys in ascending order.
+func (a *Frame) SortColumns() *Frame {
+	keys := a.Keys()
+	sort.Strings(keys)
+	order := make([]int, len(keys))
+	for k, key := range keys {
+		order[k] = a.index[key]
+	}
+	a.permute(order)
+	return a
+}
+
+// move moves the key list to the position of the target list plus offset.
+func (a *Frame) move(key, target string, offset int) (*Frame, error) {
+	if err := a.Check(key, target); err != nil {
+		return nil, err
+	}
+	j, t := a.index[key], a.index[target]
+	if j == t {
+		return a, nil
+	}
+	order := make([]int, 0, len(a.lists))
+	for k := range a.lists {
+		if k == t && offset == 0 {
+			order = append(order, j)
+		}
+		if k != j {
+			order = append(order, k)
+		}
+		if k == t && offset == 1 {
+			order = append(order, j)
+		}
+	}
+	a.permute(order)
+	return a, nil
+}
+
+// permute rearranges the lists, so the k-th list is the order[k]-th one.
+func (a *Frame) permute(order []int) {
+	keys := a.Keys()
+	lists := make([]List, len(order))
+	shared := make([]bool, len(order))
+	for k, j := range order {
+		lists[k] = a.lists[j]
+		shared[k] = a.shared[j]
+		a.index[keys[j]] = k
+	}
+	a.lists = lists
+	a.shared = shared
+}
@@ -0,0 +1,105 @@
+package dt_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+	"github.com/ofunc/dt/io/csv"
+)
+
+func columnsFrame() *dt.Frame {
+	return dttest.Frame(`
+		c | a | d | b
+		1 | 2 | 3 | 4
+	`)
+}
+
+func assertKeys(t *testing.T, frame *dt.Frame, want string) {
+	t.Helper()
+	if got := strings.Join(frame.Keys(), ","); got != want {
+		t.Errorf("got keys %v, want %v", got, want)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	frame := columnsFrame().Reorder("b", "a")
+	assertKeys(t, frame, "b,a,c,d")
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		b | a | c | d
+		4 | 2 | 1 | 3
+	`), frame)
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter().Write(frame, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "b,a,c,d\n4,2,1,3\n" {
+		t.Errorf("got %q", got)
+	}
+
+	for _, keys := range [][]string{{"a", "x"}, {"a", "a"}} {
+		if _, err := frame.TryReorder(keys...); err == nil {
+			t.Errorf("keys %v are accepted", keys)
+		}
+	}
+	assertKeys(t, frame, "b,a,c,d")
+}
+
+func TestInsert(t *testing.T) {
+	list := dt.List{dt.Int(0)}
+	assertKeys(t, columnsFrame().Insert(0, "x", list), "x,c,a,d,b")
+	assertKeys(t, columnsFrame().Insert(2, "x", list), "c,a,x,d,b")
+	assertKeys(t, columnsFrame().Insert(4, "x", list), "c,a,d,b,x")
+
+	frame := columnsFrame()
+	if _, err := frame.TryInsert(5, "x", list); err == nil {
+		t.Error("a position out of range is accepted")
+	}
+	if _, err := frame.TryInsert(-1, "x", list); err == nil {
+		t.Error("a negative position is accepted")
+	}
+	if _, err := frame.TryInsert(0, "a", list); err == nil {
+		t.Error("a key already exists is inserted")
+	}
+	if _, err := frame.TryInsert(0, "x", dt.List{}); err == nil {
+		t.Error("a list of invalid length is inserted")
+	}
+	assertKeys(t, frame, "c,a,d,b")
+}
+
+func TestMove(t *testing.T) {
+	assertKeys(t, columnsFrame().MoveBefore("b", "a"), "c,b,a,d")
+	assertKeys(t, columnsFrame().MoveBefore("c", "b"), "a,d,c,b")
+	assertKeys(t, columnsFrame().MoveAfter("c", "b"), "a,d,b,c")
+	assertKeys(t, columnsFrame().MoveAfter("b", "c"), "c,b,a,d")
+	assertKeys(t, columnsFrame().MoveAfter("a", "a"), "c,a,d,b")
+	if _, err := columnsFrame().TryMoveAfter("x", "a"); err == nil {
+		t.Error("a key not found is moved")
+	}
+}
+
+func TestSortColumns(t *testing.T) {
+	frame := columnsFrame().SortColumns()
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		a | b | c | d
+		2 | 4 | 1 | 3
+	`), frame)
+}
+
+func TestReorderCopy(t *testing.T) {
+	frame := dttest.Frame(`
+		a  | b
+		1  | NA
+	`)
+	b := frame.Copy(false).Reorder("b").FillNA(dt.Int(0), "b")
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		b | a
+		0 | 1
+	`), b)
+	if !dt.IsNA(frame.Get("b")[0]) {
+		t.Error("the original frame is changed")
+	}
+}
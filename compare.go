@@ -0,0 +1,147 @@
+package dt
+
+import (
+	"math"
+	"time"
+)
+
+// The ranks of values in the total ordering.
+const (
+	rankNull = iota
+	rankNaN
+	rankBool
+	rankNumber
+	rankString
+	rankTime
+)
+
+// Compare compares a and b in the total ordering of values, and returns -1, 0 or +1.
+//
+// Values are ordered by kinds first: null, NaN, bools, numbers, strings, times.
+// Other values are taken as strings,
+// except that categories of the same ordered dictionary are compared by their codes.
+// Numbers of Number, Int and Decimal are compared by their values, so Int(1) equals Number(1),
+// but Number(1) does not equal String("1").
+func Compare(a, b Value) int {
+	r, s := rank(a), rank(b)
+	if r != s {
+		return sign(r - s)
+	}
+	switch r {
+	case rankBool:
+		return sign(int(a.Number() - b.Number()))
+	case rankNumber:
+		return compareNumber(a, b)
+	case rankString:
+		if c, ok := a.(*Category); ok && c.dict.ordered {
+			if d, ok := b.(*Category); ok && c.dict == d.dict {
+				return sign(c.code - d.code)
+			}
+		}
+		x, y := a.String(), b.String()
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	case rankTime:
+		x, y := time.Time(a.(Time)), time.Time(b.(Time))
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return 0
+	}
+}
+
+// Less reports whether a sorts before b in the total ordering of values.
+func Less(a, b Value) bool {
+	return Compare(a, b) < 0
+}
+
+// Equal reports whether a equals b in the total ordering of values.
+func Equal(a, b Value) bool {
+	return Compare(a, b) == 0
+}
+
+// Unique returns the unique values of list a, in order of their first occurrences.
+func (a List) Unique() List {
+	t := newTable([]List{a})
+	b := make(List, 0)
+	for i, v := range a {
+		if _, ok := t.insert(i); ok {
+			b = append(b, v)
+		}
+	}
+	return b
+}
+
+func rank(v Value) int {
+	switch x := v.(type) {
+	case nil, Null:
+		return rankNull
+	case Number:
+		if math.IsNaN(float64(x)) {
+			return rankNaN
+		}
+		return rankNumber
+	case Int, Decimal:
+		return rankNumber
+	case Bool:
+		return rankBool
+	case Time:
+		return rankTime
+	default:
+		return rankString
+	}
+}
+
+func compareNumber(a, b Value) int {
+	x, ok1 := toDecimal(a)
+	y, ok2 := toDecimal(b)
+	if !ok1 {
+		x, ok1 = integral(a.Number())
+	}
+	if !ok2 {
+		y, ok2 = integral(b.Number())
+	}
+	if ok1 && ok2 {
+		return x.Cmp(y)
+	}
+	f, g := a.Number(), b.Number()
+	switch {
+	case f < g:
+		return -1
+	case f > g:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// integral converts an integral float in the int64 range to decimal.
+func integral(f float64) (Decimal, bool) {
+	if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 {
+		return Decimal{}, false
+	}
+	return Decimal{coef: int64(f)}, true
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
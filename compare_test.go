@@ -0,0 +1,74 @@
+package dt_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+)
+
+func TestCompare(t *testing.T) {
+	t0 := dt.Time(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	// values in ascending order, where the values of a group are equal.
+	groups := [][]dt.Value{
+		{nil, dt.Null{}},
+		{dt.Number(math.NaN())},
+		{dt.Bool(false)},
+		{dt.Bool(true)},
+		{dt.Number(math.Inf(-1))},
+		{dt.Int(-1), dt.Number(-1), dt.NewDecimal(-100, 2)},
+		{dt.NewDecimal(5, 1), dt.Number(0.5)},
+		{dt.Int(math.MaxInt64 - 1)},
+		{dt.Int(math.MaxInt64)},
+		{dt.Number(math.Inf(1))},
+		{dt.String("")},
+		{dt.String("1")},
+		{dt.String("a"), dt.NewCategories(false).Add("a")},
+		{t0, dt.Time(time.Time(t0).In(time.FixedZone("X", 3600)))},
+		{dt.Time(time.Time(t0).Add(time.Nanosecond))},
+	}
+	for i, xs := range groups {
+		for j, ys := range groups {
+			for _, x := range xs {
+				for _, y := range ys {
+					want := 0
+					if i < j {
+						want = -1
+					} else if i > j {
+						want = 1
+					}
+					if got := dt.Compare(x, y); got != want {
+						t.Errorf("Compare(%#v, %#v) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCompareCategories(t *testing.T) {
+	dict := dt.NewCategories(true, "low", "high")
+	low, high := dict.Get("low"), dict.Get("high")
+	if !dt.Less(low, high) || dt.Less(high, low) {
+		t.Error("ordered categories are not compared by their codes")
+	}
+	other := dt.NewCategories(true, "low", "high")
+	if !dt.Less(other.Get("high"), low) {
+		t.Error("categories of different dictionaries are not compared as strings")
+	}
+	if !dt.Equal(low, dt.String("low")) {
+		t.Error("category does not equal its string")
+	}
+}
+
+func TestSortValues(t *testing.T) {
+	l := dt.List{dt.String("b"), dt.Int(2), nil, dt.Bool(true), dt.Number(math.NaN()), dt.Number(1.5), dt.String("a")}
+	sort.SliceStable(l, func(i, j int) bool {
+		return dt.Less(l[i], l[j])
+	})
+	want := dt.List{nil, dt.Number(math.NaN()), dt.Bool(true), dt.Number(1.5), dt.Int(2), dt.String("a"), dt.String("b")}
+	assertList(t, want, l)
+	assertList(t, dt.List{dt.Int(1), dt.String("1"), nil}, dt.List{dt.Int(1), dt.Number(1), dt.String("1"), nil, dt.Null{}}.Unique())
+}
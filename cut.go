@@ -0,0 +1,206 @@
+package dt
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Cut is the cut option.
+type Cut struct {
+	list      List
+	edges     []float64
+	labels    List
+	right     bool
+	inclusive bool
+	err       error
+}
+
+// Cut returns the cut option of list a by the increasing edges.
+// The intervals are right-closed by default, such as (0, 10].
+func (a List) Cut(edges ...float64) *Cut {
+	c := &Cut{
+		list:  a,
+		edges: edges,
+		right: true,
+	}
+	for k := 1; k < len(edges); k++ {
+		if !(edges[k-1] < edges[k]) {
+			c.err = errors.New("dt: edges must be increasing")
+		}
+	}
+	if len(edges) < 2 {
+		c.err = errors.New("dt: at least 2 edges are required")
+	}
+	return c
+}
+
+// QCut returns the cut option of list a by n quantiles.
+// The outer edges are inclusive, and duplicate edges are dropped.
+func (a List) QCut(n int) *Cut {
+	if n < 1 {
+		panic("dt: invalid number of quantiles: " + strconv.Itoa(n))
+	}
+	xs := make([]float64, 0, len(a))
+	for _, v := range a {
+		if !IsNA(v) {
+			if x := v.Number(); !math.IsNaN(x) {
+				xs = append(xs, x)
+			}
+		}
+	}
+	sort.Float64s(xs)
+	var edges []float64
+	for k := 0; k <= n && len(xs) > 0; k++ {
+		x := quantile(xs, float64(k)/float64(n))
+		if len(edges) == 0 || x > edges[len(edges)-1] {
+			edges = append(edges, x)
+		}
+	}
+	if len(edges) == 1 {
+		edges = append(edges, edges[0])
+	}
+	c := a.Cut(edges...).Inclusive(true)
+	if len(edges) == 2 && edges[0] == edges[1] {
+		c.err = nil
+	}
+	return c
+}
+
+// Labels sets the labels of the intervals.
+// By default, the labels are the interval notations like "(0, 10]".
+func (a *Cut) Labels(o List) *Cut {
+	a.labels = o
+	return a
+}
+
+// Right is the right-closed option, true by default.
+func (a *Cut) Right(o bool) *Cut {
+	a.right = o
+	return a
+}
+
+// Inclusive is the option to include both outer edges.
+func (a *Cut) Inclusive(o bool) *Cut {
+	a.inclusive = o
+	return a
+}
+
+// Do returns the labels of the values.
+// NA and out of range values are nil.
+// It panics if the edges or labels are invalid.
+func (a *Cut) Do() List {
+	l, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// TryDo returns the labels of the values, or returns an error if the edges or labels are invalid.
+func (a *Cut) TryDo() (List, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	labels := a.labels
+	if labels == nil {
+		labels = make(List, len(a.edges)-1)
+		for k := range labels {
+			labels[k] = String(a.label(k))
+		}
+	} else if n, m := len(a.edges)-1, len(labels); n != m {
+		return nil, &LengthError{
+			Expected: n,
+			Got:      m,
+		}
+	}
+	l := make(List, len(a.list))
+	for i, v := range a.list {
+		if k := a.bin(v); k >= 0 {
+			l[i] = labels[k]
+		}
+	}
+	return l, nil
+}
+
+// Bounds returns the lower and upper bounds of the intervals of the values.
+// NA and out of range values are nil.
+// It panics if the edges are invalid.
+func (a *Cut) Bounds() (List, List) {
+	lower, upper, err := a.TryBounds()
+	if err != nil {
+		panic(err)
+	}
+	return lower, upper
+}
+
+// TryBounds returns the lower and upper bounds of the intervals of the values,
+// or returns an error if the edges are invalid.
+func (a *Cut) TryBounds() (List, List, error) {
+	if a.err != nil {
+		return nil, nil, a.err
+	}
+	lower := make(List, len(a.list))
+	upper := make(List, len(a.list))
+	for i, v := range a.list {
+		if k := a.bin(v); k >= 0 {
+			lower[i] = Number(a.edges[k])
+			upper[i] = Number(a.edges[k+1])
+		}
+	}
+	return lower, upper, nil
+}
+
+func (a *Cut) bin(v Value) int {
+	if IsNA(v) {
+		return -1
+	}
+	x := v.Number()
+	n := len(a.edges)
+	if math.IsNaN(x) || x < a.edges[0] || x > a.edges[n-1] {
+		return -1
+	}
+	var k int
+	if a.right {
+		k = sort.SearchFloat64s(a.edges, x) - 1
+		if k < 0 && a.inclusive {
+			k = 0
+		}
+	} else {
+		k = sort.Search(n, func(i int) bool {
+			return a.edges[i] > x
+		}) - 1
+		if k == n-1 && a.inclusive {
+			k = n - 2
+		}
+	}
+	if k < 0 || k > n-2 {
+		return -1
+	}
+	return k
+}
+
+func (a *Cut) label(k int) string {
+	lo, hi := Number(a.edges[k]).String(), Number(a.edges[k+1]).String()
+	if a.right {
+		if k == 0 && a.inclusive {
+			return "[" + lo + ", " + hi + "]"
+		}
+		return "(" + lo + ", " + hi + "]"
+	}
+	if k == len(a.edges)-2 && a.inclusive {
+		return "[" + lo + ", " + hi + "]"
+	}
+	return "[" + lo + ", " + hi + ")"
+}
+
+// quantile returns the q quantile of the sorted xs by linear interpolation.
+func quantile(xs []float64, q float64) float64 {
+	p := q * float64(len(xs)-1)
+	k := int(math.Floor(p))
+	if k >= len(xs)-1 {
+		return xs[len(xs)-1]
+	}
+	return xs[k] + (p-float64(k))*(xs[k+1]-xs[k])
+}
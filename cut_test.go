@@ -0,0 +1,58 @@
+package dt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/dt"
+)
+
+// assertList reports an error to t if list got does not equal want by dt.Equal.
+func assertList(t *testing.T, want, got dt.List) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+		return
+	}
+	for i := range want {
+		if !dt.Equal(want[i], got[i]) {
+			t.Errorf("got %v, want %v", got, want)
+			return
+		}
+	}
+}
+
+func TestCut(t *testing.T) {
+	l := dt.List{dt.Int(0), dt.Number(5), dt.Int(10), dt.Number(11), nil, dt.Number(math.NaN())}
+	assertList(t, dt.List{nil, dt.String("(0, 5]"), dt.String("(5, 10]"), nil, nil, nil}, l.Cut(0, 5, 10).Do())
+	assertList(t, dt.List{dt.String("[0, 5]"), dt.String("[0, 5]"), dt.String("(5, 10]"), nil, nil, nil},
+		l.Cut(0, 5, 10).Inclusive(true).Do())
+	assertList(t, dt.List{dt.String("[0, 5)"), dt.String("[5, 10)"), nil, nil, nil, nil}, l.Cut(0, 5, 10).Right(false).Do())
+	assertList(t, dt.List{dt.String("[0, 5)"), dt.String("[5, 10]"), dt.String("[5, 10]"), nil, nil, nil},
+		l.Cut(0, 5, 10).Right(false).Inclusive(true).Do())
+	assertList(t, dt.List{nil, dt.String("low"), dt.String("high"), nil, nil, nil},
+		l.Cut(0, 5, 10).Labels(dt.List{dt.String("low"), dt.String("high")}).Do())
+
+	lower, upper := l.Cut(0, 5, 10).Bounds()
+	assertList(t, dt.List{nil, dt.Number(0), dt.Number(5), nil, nil, nil}, lower)
+	assertList(t, dt.List{nil, dt.Number(5), dt.Number(10), nil, nil, nil}, upper)
+
+	for _, edges := range [][]float64{nil, {1}, {1, 1}, {2, 1}} {
+		if _, err := l.Cut(edges...).TryDo(); err == nil {
+			t.Errorf("list is cut by edges %v", edges)
+		}
+	}
+}
+
+func TestQCut(t *testing.T) {
+	l := dt.List{dt.Int(1), dt.Int(2), dt.Int(3), dt.Int(4), dt.Int(5), nil}
+	assertList(t, dt.List{dt.String("[1, 3]"), dt.String("[1, 3]"), dt.String("[1, 3]"),
+		dt.String("(3, 5]"), dt.String("(3, 5]"), nil}, l.QCut(2).Do())
+
+	same := dt.List{dt.Int(1), dt.Int(1), nil}
+	assertList(t, dt.List{dt.String("[1, 1]"), dt.String("[1, 1]"), nil}, same.QCut(4).Do())
+
+	if _, err := (dt.List{nil}).QCut(2).TryDo(); err == nil {
+		t.Error("list without numbers is cut")
+	}
+}
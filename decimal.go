@@ -0,0 +1,430 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxScale is the max scale of a decimal.
+const MaxScale = 18
+
+var pow10 = [MaxScale + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// Decimal is a fixed-point decimal value, coef * 10^-scale.
+// Decimals of the same number with different scales, such as 1.50 and 1.5,
+// are equal by Equal and Cmp, but not by ==, which compares the coefficients and scales.
+type Decimal struct {
+	coef  int64
+	scale uint8
+}
+
+// NewDecimal creates a decimal of coef * 10^-scale.
+// It panics if the scale is invalid.
+func NewDecimal(coef int64, scale int) Decimal {
+	return mustDecimal(TryNewDecimal(coef, scale))
+}
+
+// TryNewDecimal creates a decimal of coef * 10^-scale, or returns an error if the scale is invalid.
+func TryNewDecimal(coef int64, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{
+		coef:  coef,
+		scale: uint8(scale),
+	}, nil
+}
+
+// ParseDecimal parses a decimal like "-123.45".
+func ParseDecimal(s string) (Decimal, error) {
+	x := strings.TrimSpace(s)
+	i := strings.IndexByte(x, '.')
+	scale := 0
+	if i >= 0 {
+		scale = len(x) - i - 1
+		x = x[:i] + x[i+1:]
+	}
+	if scale > MaxScale || x == "" || x == "+" || x == "-" {
+		return Decimal{}, errors.New("dt: invalid decimal: " + s)
+	}
+	for k, c := range x {
+		if (c < '0' || c > '9') && !(k == 0 && (c == '+' || c == '-')) {
+			return Decimal{}, errors.New("dt: invalid decimal: " + s)
+		}
+	}
+	coef, err := strconv.ParseInt(x, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: %v", ErrDecimalOverflow, s)
+	}
+	return TryNewDecimal(coef, scale)
+}
+
+// Coef returns the coefficient of decimal a.
+func (a Decimal) Coef() int64 {
+	return a.coef
+}
+
+// Scale returns the scale of decimal a.
+func (a Decimal) Scale() int {
+	return int(a.scale)
+}
+
+// Number returns as a number value.
+func (a Decimal) Number() float64 {
+	if a.coef < 1<<53 && a.coef > -1<<53 {
+		return float64(a.coef) / float64(pow10[a.scale])
+	}
+	v, _ := strconv.ParseFloat(a.String(), 64)
+	return v
+}
+
+// String returns as a string value.
+func (a Decimal) String() string {
+	s := strconv.FormatInt(a.coef, 10)
+	if a.scale == 0 {
+		return s
+	}
+	sign := ""
+	if a.coef < 0 {
+		sign, s = "-", s[1:]
+	}
+	if n := int(a.scale) + 1 - len(s); n > 0 {
+		s = strings.Repeat("0", n) + s
+	}
+	k := len(s) - int(a.scale)
+	return sign + s[:k] + "." + s[k:]
+}
+
+// Format implements fmt.Formatter.
+// The verbs %f and %F with a precision round decimal a half away from zero exactly,
+// unless it overflows and is formatted as float64, %d rounds it to an integer, %e, %E, %g and %G format it as float64,
+// and other verbs format its string.
+func (a Decimal) Format(f fmt.State, verb rune) {
+	prec, hasPrec := f.Precision()
+	switch verb {
+	case 'f', 'F':
+		d, err := a, error(nil)
+		if hasPrec {
+			d, err = a.TryRound(prec)
+		}
+		if err != nil {
+			fmt.Fprintf(f, fmtFormat(f, verb), a.Number())
+			return
+		}
+		s := d.String()
+		sign := ""
+		switch {
+		case s[0] == '-':
+			sign, s = "-", s[1:]
+		case f.Flag('+'):
+			sign = "+"
+		case f.Flag(' '):
+			sign = " "
+		}
+		w, _ := f.Width()
+		if n := w - len(sign) - len(s); n > 0 {
+			switch {
+			case f.Flag('-'):
+				s += strings.Repeat(" ", n)
+			case f.Flag('0'):
+				s = strings.Repeat("0", n) + s
+			default:
+				sign = strings.Repeat(" ", n) + sign
+			}
+		}
+		io.WriteString(f, sign+s)
+	case 'd':
+		if d, err := a.TryRound(0); err == nil {
+			fmt.Fprintf(f, fmtFormat(f, verb), d.coef)
+		} else {
+			fmt.Fprintf(f, fmtFormat(f, 'f'), a.Number())
+		}
+	case 'e', 'E', 'g', 'G':
+		fmt.Fprintf(f, fmtFormat(f, verb), a.Number())
+	case 'v':
+		fmt.Fprintf(f, fmtFormat(f, 's'), a.String())
+	default:
+		fmt.Fprintf(f, fmtFormat(f, verb), a.String())
+	}
+}
+
+// Sign returns -1, 0 or +1 by the sign of decimal a.
+func (a Decimal) Sign() int {
+	switch {
+	case a.coef < 0:
+		return -1
+	case a.coef > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Neg returns -a.
+// It panics if it overflows.
+func (a Decimal) Neg() Decimal {
+	return mustDecimal(a.TryNeg())
+}
+
+// TryNeg returns -a, or returns an error if it overflows.
+func (a Decimal) TryNeg() (Decimal, error) {
+	if a.coef == math.MinInt64 {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{
+		coef:  -a.coef,
+		scale: a.scale,
+	}, nil
+}
+
+// Add returns a + b.
+// It panics if it overflows.
+func (a Decimal) Add(b Decimal) Decimal {
+	return mustDecimal(a.TryAdd(b))
+}
+
+// TryAdd returns a + b, or returns an error if it overflows.
+func (a Decimal) TryAdd(b Decimal) (Decimal, error) {
+	if c, ok := a.add(b); ok {
+		return c, nil
+	}
+	return Decimal{}, ErrDecimalOverflow
+}
+
+// Sub returns a - b.
+// It panics if it overflows.
+func (a Decimal) Sub(b Decimal) Decimal {
+	return mustDecimal(a.TrySub(b))
+}
+
+// TrySub returns a - b, or returns an error if it overflows.
+func (a Decimal) TrySub(b Decimal) (Decimal, error) {
+	b, err := b.TryNeg()
+	if err != nil {
+		return Decimal{}, err
+	}
+	return a.TryAdd(b)
+}
+
+// Mul returns a * b.
+// It panics if it overflows.
+func (a Decimal) Mul(b Decimal) Decimal {
+	return mustDecimal(a.TryMul(b))
+}
+
+// TryMul returns a * b, or returns an error if it overflows.
+func (a Decimal) TryMul(b Decimal) (Decimal, error) {
+	if c, ok := a.mul(b); ok {
+		return c, nil
+	}
+	return Decimal{}, ErrDecimalOverflow
+}
+
+// Quo returns a / b rounded half away from zero to the scale.
+// It panics if b is zero, the scale is invalid or it overflows.
+func (a Decimal) Quo(b Decimal, scale int) Decimal {
+	return mustDecimal(a.TryQuo(b, scale))
+}
+
+// TryQuo returns a / b rounded half away from zero to the scale,
+// or returns an error if b is zero, the scale is invalid or it overflows.
+func (a Decimal) TryQuo(b Decimal, scale int) (Decimal, error) {
+	if b.coef == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	// a/b = (a.coef * 10^(scale+b.scale-a.scale)) / b.coef * 10^-scale
+	x := big.NewInt(a.coef)
+	y := big.NewInt(b.coef)
+	if e := scale + int(b.scale) - int(a.scale); e >= 0 {
+		x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e)), nil))
+	} else {
+		y.Mul(y, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-e)), nil))
+	}
+	q := roundQuo(x, y)
+	if !q.IsInt64() {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{
+		coef:  q.Int64(),
+		scale: uint8(scale),
+	}, nil
+}
+
+// Round rounds decimal a half away from zero to the scale.
+// It panics if the scale is invalid or it overflows.
+func (a Decimal) Round(scale int) Decimal {
+	return mustDecimal(a.TryRound(scale))
+}
+
+// TryRound rounds decimal a half away from zero to the scale,
+// or returns an error if the scale is invalid or it overflows.
+func (a Decimal) TryRound(scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+	if scale >= int(a.scale) {
+		if c, ok := a.rescale(scale); ok {
+			return c, nil
+		}
+		return Decimal{}, ErrDecimalOverflow
+	}
+	p := pow10[int(a.scale)-scale]
+	q, r := a.coef/p, a.coef%p
+	if r >= p-r && r > 0 {
+		q++
+	} else if r < 0 && -r >= p+r {
+		q--
+	}
+	return Decimal{
+		coef:  q,
+		scale: uint8(scale),
+	}, nil
+}
+
+// Cmp compares decimal a and b, and returns -1, 0 or +1.
+func (a Decimal) Cmp(b Decimal) int {
+	s := int(a.scale)
+	if int(b.scale) > s {
+		s = int(b.scale)
+	}
+	x, ok1 := a.rescale(s)
+	y, ok2 := b.rescale(s)
+	if ok1 && ok2 {
+		switch {
+		case x.coef < y.coef:
+			return -1
+		case x.coef > y.coef:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return a.big(s).Cmp(b.big(s))
+}
+
+func (a Decimal) add(b Decimal) (Decimal, bool) {
+	s := int(a.scale)
+	if int(b.scale) > s {
+		s = int(b.scale)
+	}
+	x, ok1 := a.rescale(s)
+	y, ok2 := b.rescale(s)
+	if !ok1 || !ok2 {
+		return Decimal{}, false
+	}
+	c := x.coef + y.coef
+	if (c > x.coef) != (y.coef > 0) {
+		return Decimal{}, false
+	}
+	return Decimal{
+		coef:  c,
+		scale: uint8(s),
+	}, true
+}
+
+func (a Decimal) mul(b Decimal) (Decimal, bool) {
+	s := int(a.scale) + int(b.scale)
+	c, ok := mul64(a.coef, b.coef)
+	if !ok || s > MaxScale {
+		return Decimal{}, false
+	}
+	return Decimal{
+		coef:  c,
+		scale: uint8(s),
+	}, true
+}
+
+func (a Decimal) rescale(scale int) (Decimal, bool) {
+	if scale < int(a.scale) {
+		return a.Round(scale), true
+	}
+	c, ok := mul64(a.coef, pow10[scale-int(a.scale)])
+	return Decimal{
+		coef:  c,
+		scale: uint8(scale),
+	}, ok
+}
+
+func (a Decimal) big(scale int) *big.Int {
+	x := big.NewInt(a.coef)
+	e := big.NewInt(int64(scale - int(a.scale)))
+	return x.Mul(x, e.Exp(big.NewInt(10), e, nil))
+}
+
+func mul64(x, y int64) (int64, bool) {
+	if x == 0 || y == 0 {
+		return 0, true
+	}
+	c := x * y
+	if c/y != x || (x == -1 && y == math.MinInt64) || (y == -1 && x == math.MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+func roundQuo(x, y *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if r.CmpAbs(y) >= 0 {
+		if (x.Sign() < 0) != (y.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// fmtFormat returns the format of the flags, width and precision of f with the verb.
+func fmtFormat(f fmt.State, verb rune) string {
+	format := "%"
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			format += string(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(p)
+	}
+	return format + string(verb)
+}
+
+func checkScale(scale int) error {
+	if scale < 0 || scale > MaxScale {
+		return fmt.Errorf("%w: %v", ErrDecimalScale, scale)
+	}
+	return nil
+}
+
+func mustDecimal(d Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// toDecimal converts Int and Decimal values to decimal.
+func toDecimal(v Value) (Decimal, bool) {
+	switch x := v.(type) {
+	case Int:
+		return Decimal{coef: int64(x)}, true
+	case Decimal:
+		return x, true
+	default:
+		return Decimal{}, false
+	}
+}
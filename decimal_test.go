@@ -0,0 +1,140 @@
+package dt_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ofunc/dt"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		err  bool
+	}{
+		{s: "-123.45", want: "-123.45"},
+		{s: " 1.50 ", want: "1.50"},
+		{s: "+7", want: "7"},
+		{s: "92233720368547758.07", want: "92233720368547758.07"},
+		{s: "92233720368547758.08", err: true},
+		{s: "1.2.3", err: true},
+		{s: "-", err: true},
+		{s: "", err: true},
+	}
+	for _, tt := range tests {
+		d, err := dt.ParseDecimal(tt.s)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseDecimal(%q) = %v, want an error", tt.s, d)
+			}
+			continue
+		}
+		if err != nil || d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %v, %v, want %v", tt.s, d, err, tt.want)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		d     dt.Decimal
+		scale int
+		want  string
+	}{
+		{dt.NewDecimal(125, 2), 1, "1.3"},
+		{dt.NewDecimal(-125, 2), 1, "-1.3"},
+		{dt.NewDecimal(124, 2), 1, "1.2"},
+		{dt.NewDecimal(-124, 2), 0, "-1"},
+		{dt.NewDecimal(15, 1), 3, "1.500"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Round(tt.scale).String(); got != tt.want {
+			t.Errorf("%v.Round(%d) = %v, want %v", tt.d, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	max := dt.NewDecimal(1<<63-1, 2)
+	for name, f := range map[string]func() (dt.Decimal, error){
+		"Add": func() (dt.Decimal, error) {
+			return max.TryAdd(dt.NewDecimal(1, 2))
+		},
+		"Sub": func() (dt.Decimal, error) {
+			return max.Neg().TrySub(dt.NewDecimal(2, 2))
+		},
+		"Mul": func() (dt.Decimal, error) {
+			return max.TryMul(dt.NewDecimal(2, 0))
+		},
+		"Quo": func() (dt.Decimal, error) {
+			return max.TryQuo(dt.NewDecimal(1, 2), 2)
+		},
+		"Round": func() (dt.Decimal, error) {
+			return max.TryRound(4)
+		},
+		"Neg": func() (dt.Decimal, error) {
+			return dt.NewDecimal(-1<<63, 0).TryNeg()
+		},
+	} {
+		if d, err := f(); !errors.Is(err, dt.ErrDecimalOverflow) {
+			t.Errorf("%s = %v, %v, want an overflow error", name, d, err)
+		}
+	}
+	if _, err := max.TryQuo(dt.NewDecimal(0, 2), 2); !errors.Is(err, dt.ErrDivisionByZero) {
+		t.Errorf("got %v, want a division by zero error", err)
+	}
+	if _, err := dt.TryNewDecimal(1, dt.MaxScale+1); !errors.Is(err, dt.ErrDecimalScale) {
+		t.Errorf("got %v, want a scale error", err)
+	}
+	func() {
+		defer func() {
+			if e, ok := recover().(error); !ok || !errors.Is(e, dt.ErrDecimalOverflow) {
+				t.Errorf("got panic %v, want an overflow error", e)
+			}
+		}()
+		max.Add(max)
+	}()
+
+	// the list operations fall back to numbers on overflow.
+	if v := (dt.List{max}).Add(dt.List{dt.NewDecimal(1, 2)})[0]; !isNumber(v) {
+		t.Errorf("Add gives %T, want dt.Number", v)
+	}
+	if v := (dt.List{dt.Int(1<<63 - 1)}).Mul(dt.List{dt.Int(2)})[0]; !isNumber(v) {
+		t.Errorf("Mul gives %T, want dt.Number", v)
+	}
+}
+
+func TestDecimalEqual(t *testing.T) {
+	a, b := dt.NewDecimal(150, 2), dt.NewDecimal(15, 1)
+	if a == b || !dt.Equal(a, b) || a.Cmp(b) != 0 {
+		t.Errorf("got a == b: %v, Equal: %v, Cmp: %v", a == b, dt.Equal(a, b), a.Cmp(b))
+	}
+}
+
+func TestExactSum(t *testing.T) {
+	tests := []struct {
+		l    dt.List
+		want dt.Value
+	}{
+		{dt.List{dt.Int(1 << 60), dt.Int(1)}, dt.Int(1<<60 + 1)},
+		{dt.List{dt.NewDecimal(10, 1), dt.NewDecimal(2, 2), dt.Int(3)}, dt.NewDecimal(402, 2)},
+		{dt.List{dt.NewDecimal(1, 1), dt.Number(0.2)}, dt.Number(0.30000000000000004)},
+	}
+	for _, tt := range tests {
+		if got := dt.Sum(tt.l); !same(got, tt.want) {
+			t.Errorf("Sum(%v) = %#v, want %#v", tt.l, got, tt.want)
+		}
+	}
+}
+
+func isNumber(v dt.Value) bool {
+	_, ok := v.(dt.Number)
+	return ok
+}
+
+// same reports whether a and b are equal values of the same type.
+func same(a, b dt.Value) bool {
+	return fmt.Sprintf("%T", a) == fmt.Sprintf("%T", b) && (a == nil || dt.Equal(a, b))
+}
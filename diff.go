@@ -0,0 +1,154 @@
+package dt
+
+import (
+	"math"
+	"strconv"
+)
+
+// The changes reported by Diff.
+const (
+	RowAdded      = "row added"
+	RowRemoved    = "row removed"
+	CellChanged   = "cell changed"
+	ColumnAdded   = "column added"
+	ColumnRemoved = "column removed"
+)
+
+// Differ is the diff option.
+type Differ struct {
+	a         *Frame
+	b         *Frame
+	keys      []string
+	tolerance float64
+}
+
+// Diff returns the diff option of frame a to b, whose rows are aligned by the keys.
+// Rows of duplicate keys are aligned in order of their occurrences.
+func Diff(a, b *Frame, key string, keys ...string) *Differ {
+	return &Differ{
+		a:    a,
+		b:    b,
+		keys: append([]string{key}, keys...),
+	}
+}
+
+// Tolerance is the absolute tolerance of numbers taken as unchanged, 0 by default.
+func (a *Differ) Tolerance(o float64) *Differ {
+	if o < 0 || math.IsNaN(o) {
+		panic("dt: invalid tolerance: " + strconv.FormatFloat(o, 'g', -1, 64))
+	}
+	a.tolerance = o
+	return a
+}
+
+// Do does the diff, and returns a frame of the changes with the columns:
+// "change", the keys, "column", "old" and "new".
+// The column changes come first, then the removed rows and changed cells in order of frame a,
+// and then the added rows in order of frame b.
+// It panics if any key is not found in the frames or conflicts with the columns.
+func (a *Differ) Do() *Frame {
+	frame, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the diff, or returns an error if any key is not found in the frames or conflicts with the columns.
+func (a *Differ) TryDo() (*Frame, error) {
+	keys := append(append([]string{"change"}, a.keys...), "column", "old", "new")
+	frame, err := TryNewFrame(keys...)
+	if err != nil {
+		return nil, err
+	}
+	alists, err := a.a.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+	blists, err := a.b.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+	m := len(a.keys)
+	report := func(change string, lists []List, i int, column string, old, new Value) {
+		frame.lists[0] = append(frame.lists[0], String(change))
+		for j, list := range lists {
+			var v Value
+			if i >= 0 {
+				v = list[i]
+			}
+			frame.lists[j+1] = append(frame.lists[j+1], v)
+		}
+		var c Value
+		if column != "" {
+			c = String(column)
+		}
+		frame.lists[m+1] = append(frame.lists[m+1], c)
+		frame.lists[m+2] = append(frame.lists[m+2], old)
+		frame.lists[m+3] = append(frame.lists[m+3], new)
+	}
+
+	isKey := make(map[string]bool, m)
+	for _, key := range a.keys {
+		isKey[key] = true
+	}
+	var columns []string
+	for _, key := range a.a.Keys() {
+		if isKey[key] {
+			continue
+		}
+		if a.b.Has(key) {
+			columns = append(columns, key)
+		} else {
+			report(ColumnRemoved, alists, -1, key, nil, nil)
+		}
+	}
+	for _, key := range a.b.Keys() {
+		if !isKey[key] && !a.a.Has(key) {
+			report(ColumnAdded, alists, -1, key, nil, nil)
+		}
+	}
+
+	t := newTable(blists)
+	var groups [][]int
+	for i, n := 0, a.b.Len(); i < n; i++ {
+		if g, ok := t.insert(i); ok {
+			groups = append(groups, []int{i})
+		} else {
+			groups[g] = append(groups[g], i)
+		}
+	}
+	matched := make([]bool, a.b.Len())
+	for i, n := 0, a.a.Len(); i < n; i++ {
+		g := t.find(i, alists)
+		if g < 0 || len(groups[g]) == 0 {
+			report(RowRemoved, alists, i, "", nil, nil)
+			continue
+		}
+		k := groups[g][0]
+		groups[g] = groups[g][1:]
+		matched[k] = true
+		for _, key := range columns {
+			x, y := a.a.lists[a.a.index[key]][i], a.b.lists[a.b.index[key]][k]
+			if !a.equal(x, y) {
+				report(CellChanged, alists, i, key, x, y)
+			}
+		}
+	}
+	for k, ok := range matched {
+		if !ok {
+			report(RowAdded, blists, k, "", nil, nil)
+		}
+	}
+	return frame, nil
+}
+
+func (a *Differ) equal(x, y Value) bool {
+	if Equal(x, y) {
+		return true
+	}
+	if a.tolerance > 0 && rank(x) == rankNumber && rank(y) == rankNumber {
+		return math.Abs(x.Number()-y.Number()) <= a.tolerance
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package dt_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestDiff(t *testing.T) {
+	before := dttest.Frame(`
+		id | name | amount | dropped
+		1  | a    | 1.00   | x
+		2  | b    | 2      | x
+		3  | c    | 3      | x
+		2  | b2   | 5      | x
+	`)
+	after := dttest.Frame(`
+		id | name | amount | extra
+		2  | b    | 2.001  | y
+		1  | a    | 1.5    | y
+		4  | d    | 4      | y
+		2  | b2   | 5      | y
+	`)
+	got := dt.Diff(before, after, "id").Tolerance(0.01).Do()
+	want := dttest.Frame(`
+		change         | id | column  | old  | new
+		column removed | NA | dropped | NA   | NA
+		column added   | NA | extra   | NA   | NA
+		cell changed   | 1  | amount  | 1.00 | 1.5
+		row removed    | 3  | NA      | NA   | NA
+		row added      | 4  | NA      | NA   | NA
+	`)
+	dttest.AssertFrameEqual(t, want, got)
+
+	// the duplicate keys are aligned in order, so only the first row of 2 changes without tolerance.
+	got = dt.Diff(before, after, "id").Do().Filter(func(r dt.Record) bool {
+		return r.String("change") == dt.CellChanged
+	})
+	want = dttest.Frame(`
+		change       | id | column | old  | new
+		cell changed | 1  | amount | 1.00 | 1.5
+		cell changed | 2  | amount | 2    | 2.001
+	`)
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestDiffError(t *testing.T) {
+	a := dttest.Frame(`
+		id | change
+		1  | x
+	`)
+	if _, err := dt.Diff(a, a, "x").TryDo(); err == nil {
+		t.Error("a key not found is accepted")
+	}
+	if _, err := dt.Diff(a, a, "change").TryDo(); err == nil {
+		t.Error("a key conflicting with the columns is accepted")
+	}
+}
@@ -2,27 +2,25 @@ package dt
 
 import (
 	"math"
-	"strings"
 )
 
-// IsNA checks if a is NA.
+// IsNA checks if a is NA, that is either null or NaN.
 func IsNA(a Value) bool {
-	switch v := a.(type) {
-	case nil:
+	return IsNull(a) || IsNaN(a)
+}
+
+// IsNull checks if a is missing, that is nil or Null.
+func IsNull(a Value) bool {
+	switch a.(type) {
+	case nil, Null:
 		return true
-	case Number:
-		return math.IsNaN(float64(v))
 	default:
 		return false
 	}
 }
 
-func makeKey(i int, lists []List) string {
-	ks := make([]string, len(lists))
-	for j, list := range lists {
-		if v := list[i]; v != nil {
-			ks[j] = v.String()
-		}
-	}
-	return strings.Join(ks, "\r\t\n")
+// IsNaN checks if a is a NaN number, such as the result of 0/0.
+func IsNaN(a Value) bool {
+	v, ok := a.(Number)
+	return ok && math.IsNaN(float64(v))
 }
@@ -0,0 +1,219 @@
+// Package dttest provides the helpers to test code using dt frames.
+package dttest
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/dt"
+	util "github.com/ofunc/dt/io"
+)
+
+// maxDiffs is the maximum number of cell differences in a message.
+const maxDiffs = 10
+
+var regSeparator = regexp.MustCompile(`^\|?(\s*:?-{3,}:?\s*[|+])*\s*:?-{3,}:?\s*\|?$`)
+
+// Option is an option of frame comparisons.
+type Option func(*options)
+
+type options struct {
+	tolerance         float64
+	ignoreRowOrder    bool
+	ignoreColumnOrder bool
+}
+
+// Tolerance is the absolute tolerance of numbers taken as equal.
+func Tolerance(o float64) Option {
+	if o < 0 || math.IsNaN(o) {
+		panic("dt/dttest: invalid tolerance: " + strconv.FormatFloat(o, 'g', -1, 64))
+	}
+	return func(a *options) {
+		a.tolerance = o
+	}
+}
+
+// IgnoreRowOrder compares the rows sorted by all the columns.
+// Rows of numbers differing within the tolerance may be sorted differently.
+func IgnoreRowOrder() Option {
+	return func(a *options) {
+		a.ignoreRowOrder = true
+	}
+}
+
+// IgnoreColumnOrder compares the columns by keys regardless of their order.
+func IgnoreColumnOrder() Option {
+	return func(a *options) {
+		a.ignoreColumnOrder = true
+	}
+}
+
+// AssertFrameEqual reports an error to t with the differences, if frame got does not equal want.
+// Values are equal by dt.Equal, and it returns whether the frames are equal.
+func AssertFrameEqual(t testing.TB, want, got *dt.Frame, opts ...Option) bool {
+	t.Helper()
+	if d := Diff(want, got, opts...); d != "" {
+		t.Errorf("frames are not equal:\n%s\nwant:\n%s\ngot:\n%s", d, format(want), format(got))
+		return false
+	}
+	return true
+}
+
+// Diff returns the description of the differences of frame got from want,
+// or the empty string if they are equal.
+func Diff(want, got *dt.Frame, opts ...Option) string {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	wkeys, gkeys := want.Keys(), got.Keys()
+	if !equalKeys(wkeys, gkeys, o.ignoreColumnOrder) {
+		return fmt.Sprintf("columns: want %q, got %q", wkeys, gkeys)
+	}
+	if n, m := want.Len(), got.Len(); n != m {
+		return fmt.Sprintf("rows: want %d, got %d", n, m)
+	}
+	if want.Len() == 0 {
+		return ""
+	}
+	if o.ignoreRowOrder {
+		want = want.Copy(false).SortBy(wkeys[0], wkeys[1:]...)
+		got = got.Copy(false).SortBy(wkeys[0], wkeys[1:]...)
+	}
+
+	var diffs []string
+	count := 0
+	for i, n := 0, want.Len(); i < n; i++ {
+		for _, key := range wkeys {
+			x, y := want.Get(key)[i], got.Get(key)[i]
+			if o.equal(x, y) {
+				continue
+			}
+			count++
+			if count <= maxDiffs {
+				diffs = append(diffs, fmt.Sprintf("row %d, column %q: want %s, got %s", i, key, value(x), value(y)))
+			}
+		}
+	}
+	if count > maxDiffs {
+		diffs = append(diffs, fmt.Sprintf("... %d more", count-maxDiffs))
+	}
+	return strings.Join(diffs, "\n")
+}
+
+// Frame creates a frame from an inline table,
+// whose lines are the rows of cells separated by "|", and the first row is the keys.
+// The cells are parsed by io.Value, and the empty and "NA" cells are nil.
+// The rows may have the borders of "|" if the first row has them.
+// The blank lines are ignored, and so is the line right after the keys
+// if its cells are all at least 3 dashes with optional colons, such as "---|:---:". For example:
+//
+//	dttest.Frame(`
+//		id | name  | amount
+//		1  | apple | 1.50
+//		2  | NA    |
+//	`)
+//
+// It panics if the table is invalid.
+func Frame(table string) *dt.Frame {
+	var rows [][]string
+	bordered := false
+	for _, line := range strings.Split(table, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || len(rows) == 1 && regSeparator.MatchString(line) {
+			continue
+		}
+		if len(rows) == 0 {
+			bordered = strings.HasPrefix(line, "|")
+		}
+		if bordered {
+			line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+		}
+		cells := strings.Split(line, "|")
+		for j, c := range cells {
+			cells[j] = strings.TrimSpace(c)
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		panic("dt/dttest: empty table")
+	}
+	frame := dt.NewFrame(rows[0]...)
+	lists := frame.Lists()
+	for i, r := range rows[1:] {
+		if len(r) != len(lists) {
+			panic(fmt.Sprintf("dt/dttest: row %d has %d cells, want %d", i, len(r), len(lists)))
+		}
+		for j, c := range r {
+			var v dt.Value
+			if c != "" && c != "NA" {
+				v = util.Value(c)
+			}
+			lists[j] = append(lists[j], v)
+		}
+	}
+	return frame
+}
+
+func (a options) equal(x, y dt.Value) bool {
+	if dt.Equal(x, y) {
+		return true
+	}
+	if a.tolerance > 0 && isNumber(x) && isNumber(y) {
+		return math.Abs(x.Number()-y.Number()) <= a.tolerance
+	}
+	return false
+}
+
+func isNumber(v dt.Value) bool {
+	switch v.(type) {
+	case dt.Number, dt.Int, dt.Decimal:
+		return !dt.IsNaN(v)
+	}
+	return false
+}
+
+func equalKeys(want, got []string, ignoreOrder bool) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	if ignoreOrder {
+		m := make(map[string]bool, len(want))
+		for _, key := range want {
+			m[key] = true
+		}
+		for _, key := range got {
+			if !m[key] {
+				return false
+			}
+		}
+		return true
+	}
+	for j, key := range want {
+		if got[j] != key {
+			return false
+		}
+	}
+	return true
+}
+
+// value formats value v with its type.
+func value(v dt.Value) string {
+	if dt.IsNull(v) {
+		return "NA"
+	}
+	t := strings.Replace(fmt.Sprintf("%T", v), "dt.", "", 1)
+	if _, ok := v.(dt.String); ok {
+		return fmt.Sprintf("%q (%s)", v.String(), t)
+	}
+	return fmt.Sprintf("%s (%s)", v.String(), t)
+}
+
+func format(frame *dt.Frame) string {
+	return dt.NewFormatter().Head(-1).String(frame)
+}
@@ -0,0 +1,186 @@
+package dttest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+// recorder records the errors reported by AssertFrameEqual.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (a *recorder) Helper() {}
+
+func (a *recorder) Errorf(format string, args ...interface{}) {
+	a.errors = append(a.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFrame(t *testing.T) {
+	got := dttest.Frame(`
+		id | name  | amount
+		---|-------|-------
+		1  | apple | 1.50
+		2  | NA    |
+	`)
+	want := dt.NewFrame()
+	want.Add("id", dt.List{dt.Int(1), dt.Int(2)})
+	want.Add("name", dt.List{dt.String("apple"), nil})
+	want.Add("amount", dt.List{dt.NewDecimal(150, 2), nil})
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestFrameBordered(t *testing.T) {
+	got := dttest.Frame(`
+		| id | name |
+		|:---|-----:|
+		|    | a    |
+		| 2  | b    |
+	`)
+	want := dt.NewFrame()
+	want.Add("id", dt.List{nil, dt.Int(2)})
+	want.Add("name", dt.List{dt.String("a"), dt.String("b")})
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestFrameDashRows(t *testing.T) {
+	got := dttest.Frame(`
+		sign
+		-
+		+
+		---
+	`)
+	want := dt.NewFrame()
+	want.Add("sign", dt.List{dt.String("-"), dt.String("+"), dt.String("---")})
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestFrameInvalid(t *testing.T) {
+	for _, table := range []string{"", "a | b\n1"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Frame(%q) did not panic", table)
+				}
+			}()
+			dttest.Frame(table)
+		}()
+	}
+}
+
+func TestDiff(t *testing.T) {
+	want := dttest.Frame(`
+		id | name  | amount
+		1  | apple | 1.50
+		2  | pear  | 2
+	`)
+	tests := []struct {
+		name string
+		got  string
+		opts []dttest.Option
+		diff string
+	}{{
+		name: "equal",
+		got: `
+			id | name  | amount
+			1  | apple | 1.5
+			2  | pear  | 2.0
+		`,
+	}, {
+		name: "columns",
+		got: `
+			id | amount | name
+			1  | 1.50   | apple
+			2  | 2      | pear
+		`,
+		diff: `columns: want ["id" "name" "amount"], got ["id" "amount" "name"]`,
+	}, {
+		name: "ignore column order",
+		got: `
+			id | amount | name
+			1  | 1.50   | apple
+			2  | 2      | pear
+		`,
+		opts: []dttest.Option{dttest.IgnoreColumnOrder()},
+	}, {
+		name: "rows",
+		got: `
+			id | name  | amount
+			1  | apple | 1.50
+		`,
+		diff: "rows: want 2, got 1",
+	}, {
+		name: "cells",
+		got: `
+			id | name  | amount
+			1  | 1     | 1.50
+			2  | pear  | NA
+		`,
+		diff: `row 0, column "name": want "apple" (String), got 1 (Int)` + "\n" +
+			`row 1, column "amount": want 2 (Int), got NA`,
+	}, {
+		name: "tolerance",
+		got: `
+			id | name  | amount
+			1  | apple | 1.5000001
+			2  | pear  | 1.9999999
+		`,
+		opts: []dttest.Option{dttest.Tolerance(1e-6)},
+	}, {
+		name: "ignore row order",
+		got: `
+			id | name  | amount
+			2  | pear  | 2
+			1  | apple | 1.50
+		`,
+		opts: []dttest.Option{dttest.IgnoreRowOrder()},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := dttest.Diff(want, dttest.Frame(tt.got), tt.opts...); d != tt.diff {
+				t.Errorf("got %q, want %q", d, tt.diff)
+			}
+		})
+	}
+}
+
+func TestDiffMore(t *testing.T) {
+	x, y := make(dt.List, 15), make(dt.List, 15)
+	for i := range x {
+		x[i], y[i] = dt.Int(i), dt.Int(-i-1)
+	}
+	d := dttest.Diff(dt.NewFrame().Add("x", x), dt.NewFrame().Add("x", y))
+	lines := strings.Split(d, "\n")
+	if n := len(lines); n != 11 {
+		t.Fatalf("got %d lines, want 11:\n%s", n, d)
+	}
+	if last := lines[10]; last != "... 5 more" {
+		t.Errorf("got %q, want %q", last, "... 5 more")
+	}
+}
+
+func TestAssertFrameEqual(t *testing.T) {
+	want := dttest.Frame(`
+		id | name
+		1  | apple
+	`)
+	r := new(recorder)
+	if !dttest.AssertFrameEqual(r, want, want.Copy(true)) || len(r.errors) != 0 {
+		t.Errorf("equal frames are reported: %q", r.errors)
+	}
+	got := dttest.Frame(`
+		id | name
+		1  | pear
+	`)
+	if dttest.AssertFrameEqual(r, want, got) || len(r.errors) != 1 {
+		t.Fatalf("unequal frames are not reported: %q", r.errors)
+	}
+	if e := r.errors[0]; !strings.Contains(e, `row 0, column "name": want "apple" (String), got "pear" (String)`) {
+		t.Errorf("got %q", e)
+	}
+}
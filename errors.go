@@ -0,0 +1,89 @@
+package dt
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyNotFound is the error of a key not found.
+	ErrKeyNotFound = errors.New("dt: key not found")
+	// ErrKeyExists is the error of a key already exists.
+	ErrKeyExists = errors.New("dt: key already exists")
+	// ErrLengthMismatch is the error of lengths not match.
+	ErrLengthMismatch = errors.New("dt: invalid list length")
+	// ErrIndexOutOfRange is the error of an index out of range.
+	ErrIndexOutOfRange = errors.New("dt: index out of range")
+	// ErrKeyCount is the error of the numbers of keys not match.
+	ErrKeyCount = errors.New("dt: numbers of keys not match")
+	// ErrDecimalOverflow is the error of a decimal overflow.
+	ErrDecimalOverflow = errors.New("dt: decimal overflow")
+	// ErrDecimalScale is the error of an invalid decimal scale.
+	ErrDecimalScale = errors.New("dt: invalid decimal scale")
+	// ErrDivisionByZero is the error of a decimal division by zero.
+	ErrDivisionByZero = errors.New("dt: decimal division by zero")
+	// ErrNotBool is the error of a value not a bool in masks.
+	ErrNotBool = errors.New("dt: not a bool value")
+)
+
+// KeyError is the error of a key.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+// Error returns the error message.
+func (e *KeyError) Error() string {
+	return e.Err.Error() + ": " + e.Key
+}
+
+// Unwrap returns the underlying error.
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// LengthError is the error of lengths not match.
+type LengthError struct {
+	Expected int
+	Got      int
+}
+
+// Error returns the error message.
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("dt: invalid list length, expected %v, got %v", e.Expected, e.Got)
+}
+
+// Is reports whether the target is ErrLengthMismatch.
+func (e *LengthError) Is(target error) bool {
+	return target == ErrLengthMismatch
+}
+
+// IndexError is the error of an index out of range.
+type IndexError struct {
+	Index int
+	Len   int
+}
+
+// Error returns the error message.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("dt: index out of range [%v] with length %v", e.Index, e.Len)
+}
+
+// Is reports whether the target is ErrIndexOutOfRange.
+func (e *IndexError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+func keyNotFound(key string) error {
+	return &KeyError{
+		Key: key,
+		Err: ErrKeyNotFound,
+	}
+}
+
+func keyExists(key string) error {
+	return &KeyError{
+		Key: key,
+		Err: ErrKeyExists,
+	}
+}
@@ -0,0 +1,62 @@
+package dt
+
+import (
+	"strings"
+)
+
+// Explode splits the strings of the key list by sep into rows, and returns a new frame,
+// whose other lists are repeated for the parts. NA values are kept as one row.
+// It panics if the key is not found.
+func (a *Frame) Explode(key, sep string) *Frame {
+	b, err := a.TryExplode(key, sep)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryExplode splits the strings of the key list by sep into rows, and returns a new frame,
+// or returns an error if the key is not found.
+func (a *Frame) TryExplode(key, sep string) (*Frame, error) {
+	list, err := a.TryGet(key)
+	if err != nil {
+		return nil, err
+	}
+	parts := make(List, 0, len(list))
+	is := make([]int, 0, len(list))
+	for i, v := range list {
+		if IsNA(v) {
+			parts = append(parts, v)
+			is = append(is, i)
+			continue
+		}
+		for _, p := range strings.Split(v.String(), sep) {
+			parts = append(parts, String(p))
+			is = append(is, i)
+		}
+	}
+	b := a.take(is)
+	b.lists[b.index[key]] = parts
+	return b, nil
+}
+
+// Implode joins the strings of the key list by sep in the groups of the keys, and returns a new frame
+// of the group keys and the joined list, which reverses Explode.
+// It panics if any key is not found.
+func (a *Frame) Implode(key, sep string, by string, keys ...string) *Frame {
+	b, err := a.TryImplode(key, sep, by, keys...)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryImplode joins the strings of the key list by sep in the groups of the keys, and returns a new frame,
+// or returns an error if any key is not found.
+func (a *Frame) TryImplode(key, sep string, by string, keys ...string) (*Frame, error) {
+	g, err := a.TryGroupBy(by, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return g.Apply(key, key, StringJoin(sep)).TryDo()
+}
@@ -0,0 +1,44 @@
+package dt_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestExplode(t *testing.T) {
+	frame := dttest.Frame(`
+		id | tags
+		1  | a;b;c
+		2  | NA
+		3  | d
+	`)
+	frame.Get("tags")[1] = dt.Null{}
+	got := frame.Explode("tags", ";")
+	want := dttest.Frame(`
+		id | tags
+		1  | a
+		1  | b
+		1  | c
+		2  | NA
+		3  | d
+	`)
+	dttest.AssertFrameEqual(t, want, got)
+	if _, ok := got.Get("tags")[3].(dt.Null); !ok {
+		t.Errorf("got %#v, want the NA value kept", got.Get("tags")[3])
+	}
+	if frame.Len() != 3 {
+		t.Error("the original frame is changed")
+	}
+
+	got = got.Implode("tags", ";", "id")
+	dttest.AssertFrameEqual(t, frame, got)
+
+	if _, err := frame.TryExplode("x", ";"); err == nil {
+		t.Error("a key not found is accepted")
+	}
+	if _, err := frame.TryImplode("tags", ";", "x"); err == nil {
+		t.Error("a key not found is accepted")
+	}
+}
@@ -0,0 +1,575 @@
+package dt
+
+import (
+	"container/heap"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	// fanIn is the maximum number of runs merged at once.
+	fanIn = 64
+	// partitions is the number of hash partitions of a spilled group.
+	partitions = 64
+	// maxDepth is the maximum depth of repartitioning.
+	maxDepth = 3
+	// spillChunk is the number of rows read back from a spill at once.
+	spillChunk = 1 << 16
+)
+
+// ExternalSort sorts rows which may not fit in memory,
+// by spilling sorted runs to temporary files and merging them.
+type ExternalSort struct {
+	keys   []string
+	budget int64
+	chunk  int
+	dir    string
+	buffer *Frame
+	size   int64
+	runs   []*spill
+	dicts  spillDicts
+}
+
+// NewExternalSort creates an external sort by the keys in ascending order,
+// which gives the same order as Frame.SortBy.
+func NewExternalSort(key string, keys ...string) *ExternalSort {
+	return &ExternalSort{
+		keys:   append([]string{key}, keys...),
+		budget: 1 << 28,
+		chunk:  spillChunk,
+	}
+}
+
+// Budget is the approximate memory budget of the buffered rows in bytes, 256MiB by default.
+func (a *ExternalSort) Budget(o int64) *ExternalSort {
+	if o <= 0 {
+		panic("dt: invalid budget: " + strconv.FormatInt(o, 10))
+	}
+	a.budget = o
+	return a
+}
+
+// Chunk is the maximum number of rows of the frames passed to Do, 65536 by default.
+func (a *ExternalSort) Chunk(o int) *ExternalSort {
+	if o <= 0 {
+		panic("dt: invalid chunk: " + strconv.Itoa(o))
+	}
+	a.chunk = o
+	return a
+}
+
+// Dir is the directory of the temporary files, os.TempDir() by default.
+func (a *ExternalSort) Dir(o string) *ExternalSort {
+	a.dir = o
+	return a
+}
+
+// Add adds the rows of the frame, which must have the keys of the first added frame.
+// The rows are spilled to a temporary file when the budget is exceeded.
+func (a *ExternalSort) Add(frame *Frame) error {
+	if a.buffer == nil {
+		if err := frame.Check(a.keys...); err != nil {
+			return err
+		}
+		a.buffer = frame.Empty()
+		a.buffer.budget = 0
+	}
+	n := a.buffer.Len()
+	if _, err := a.buffer.TryConcat(frame); err != nil {
+		return err
+	}
+	a.size += rowsSize(a.buffer.lists, n, a.buffer.Len())
+	if a.size > a.budget {
+		return a.spill()
+	}
+	return nil
+}
+
+// Do sorts the added rows, and calls f with the sorted frames of at most Chunk rows in order.
+// The temporary files are removed when Do returns.
+func (a *ExternalSort) Do(f func(*Frame) error) error {
+	defer a.remove()
+	if a.buffer == nil {
+		return nil
+	}
+	if len(a.runs) == 0 {
+		a.buffer.SortBy(a.keys[0], a.keys[1:]...)
+		for i, n := 0, a.buffer.Len(); i < n; i += a.chunk {
+			j := i + a.chunk
+			if j > n {
+				j = n
+			}
+			if err := f(a.buffer.Slice(i, j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if a.buffer.Len() > 0 {
+		if err := a.spill(); err != nil {
+			return err
+		}
+	}
+
+	// the runs are merged by at most fanIn at once, into a run in place of them.
+	runs := a.runs
+	for len(runs) > fanIn {
+		s, err := newSpill(a.dir, &a.dicts)
+		if err != nil {
+			return err
+		}
+		a.runs = append(a.runs, s)
+		w, err := s.writer()
+		if err != nil {
+			return err
+		}
+		err = a.merge(runs[:fanIn], w.writeRow)
+		if e := w.close(); err == nil {
+			err = e
+		}
+		if err != nil {
+			return err
+		}
+		runs = append([]*spill{s}, runs[fanIn:]...)
+	}
+
+	frame := a.buffer.Empty()
+	err := a.merge(runs, func(row []Value) error {
+		for j, v := range row {
+			frame.lists[j] = append(frame.lists[j], v)
+		}
+		if frame.Len() < a.chunk {
+			return nil
+		}
+		b := frame
+		frame = frame.Empty()
+		return f(b)
+	})
+	if err != nil {
+		return err
+	}
+	if frame.Len() > 0 {
+		return f(frame)
+	}
+	return nil
+}
+
+func (a *ExternalSort) spill() error {
+	a.buffer.SortBy(a.keys[0], a.keys[1:]...)
+	s, err := newSpill(a.dir, &a.dicts)
+	if err != nil {
+		return err
+	}
+	a.runs = append(a.runs, s)
+	w, err := s.writer()
+	if err != nil {
+		return err
+	}
+	err = w.writeRows(a.buffer.lists, 0, a.buffer.Len())
+	if e := w.close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return err
+	}
+	a.buffer = a.buffer.Empty()
+	a.size = 0
+	return nil
+}
+
+// merge merges the sorted runs, and calls f with the rows in order.
+func (a *ExternalSort) merge(runs []*spill, f func([]Value) error) error {
+	h := &merger{
+		keys: make([]int, len(a.keys)),
+	}
+	for k, key := range a.keys {
+		h.keys[k] = a.buffer.index[key]
+	}
+	for seq, s := range runs {
+		r, err := s.reader()
+		if err != nil {
+			return err
+		}
+		defer r.close()
+		row := make([]Value, len(a.buffer.lists))
+		if err := r.readRow(row); err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		h.runs = append(h.runs, run{
+			reader: r,
+			row:    row,
+			seq:    seq,
+		})
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		r := &h.runs[0]
+		if err := f(r.row); err != nil {
+			return err
+		}
+		if err := r.reader.readRow(r.row); err == io.EOF {
+			heap.Pop(h)
+		} else if err != nil {
+			return err
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+	return nil
+}
+
+func (a *ExternalSort) remove() {
+	for _, s := range a.runs {
+		s.remove()
+	}
+	a.runs = nil
+	a.buffer = nil
+	a.size = 0
+}
+
+// run is the current row of a sorted run.
+type run struct {
+	reader *spillReader
+	row    []Value
+	seq    int
+}
+
+// merger is a heap of sorted runs, ordered by their current rows and then the runs.
+type merger struct {
+	keys []int
+	runs []run
+}
+
+func (a *merger) Len() int {
+	return len(a.runs)
+}
+
+func (a *merger) Less(i, j int) bool {
+	x, y := a.runs[i], a.runs[j]
+	for _, k := range a.keys {
+		if c := Compare(x.row[k], y.row[k]); c != 0 {
+			return c < 0
+		}
+	}
+	return x.seq < y.seq
+}
+
+func (a *merger) Swap(i, j int) {
+	a.runs[i], a.runs[j] = a.runs[j], a.runs[i]
+}
+
+func (a *merger) Push(x interface{}) {
+	a.runs = append(a.runs, x.(run))
+}
+
+func (a *merger) Pop() interface{} {
+	n := len(a.runs) - 1
+	x := a.runs[n]
+	a.runs = a.runs[:n]
+	return x
+}
+
+// ExternalGroup groups rows which may not fit in memory,
+// by spilling hash partitions of the rows to temporary files and grouping them one by one.
+type ExternalGroup struct {
+	by    []string
+	keys  []string
+	names []string
+	funcs [](func(List) Value)
+	root  *partition
+}
+
+// NewExternalGroup creates an external group by the keys,
+// which gives the same groups as Frame.GroupBy.
+func NewExternalGroup(key string, keys ...string) *ExternalGroup {
+	return &ExternalGroup{
+		by: append([]string{key}, keys...),
+		root: &partition{
+			budget: 1 << 28,
+			dicts:  new(spillDicts),
+		},
+	}
+}
+
+// Budget is the approximate memory budget of the buffered rows in bytes, 256MiB by default.
+func (a *ExternalGroup) Budget(o int64) *ExternalGroup {
+	if o <= 0 {
+		panic("dt: invalid budget: " + strconv.FormatInt(o, 10))
+	}
+	a.root.budget = o
+	return a
+}
+
+// Dir is the directory of the temporary files, os.TempDir() by default.
+func (a *ExternalGroup) Dir(o string) *ExternalGroup {
+	a.root.dir = o
+	return a
+}
+
+// Apply applies the aggregate function to the groups.
+func (a *ExternalGroup) Apply(key string, name string, f func(List) Value) *ExternalGroup {
+	a.keys = append(a.keys, key)
+	a.names = append(a.names, name)
+	a.funcs = append(a.funcs, f)
+	return a
+}
+
+// Add adds the rows of the frame, which must have the keys of the first added frame.
+// The rows are spilled to temporary files when the budget is exceeded.
+func (a *ExternalGroup) Add(frame *Frame) error {
+	if a.root.buffer == nil {
+		if err := frame.Check(a.by...); err != nil {
+			return err
+		}
+		a.root.by = a.by
+	}
+	return a.root.add(frame)
+}
+
+// Do groups the added rows, and calls f with the frames of the aggregated groups.
+// If nothing is spilled, f is called once with the groups in order of their first rows,
+// otherwise it is called for each partition of the groups.
+// The temporary files are removed when Do returns.
+func (a *ExternalGroup) Do(f func(*Frame) error) error {
+	defer func() {
+		a.root = &partition{
+			norm:   a.root.norm,
+			budget: a.root.budget,
+			dir:    a.root.dir,
+			dicts:  new(spillDicts),
+		}
+	}()
+	defer a.root.remove()
+	if a.root.buffer == nil {
+		return nil
+	}
+	return a.root.do(0, func(frame *Frame) error {
+		g, err := frame.TryGroupBy(a.by[0], a.by[1:]...)
+		if err != nil {
+			return err
+		}
+		g.norm = a.root.norm
+		for k, key := range a.keys {
+			g.Apply(key, a.names[k], a.funcs[k])
+		}
+		frame, err = g.TryDo()
+		if err != nil {
+			return err
+		}
+		return f(frame)
+	})
+}
+
+// partition is a set of rows, which is spilled to hash partitions when the budget is exceeded.
+type partition struct {
+	hasher
+	by     []string
+	norm   normalizer
+	budget int64
+	dir    string
+	buffer *Frame
+	size   int64
+	parts  []*spill
+	dicts  *spillDicts
+}
+
+func (a *partition) add(frame *Frame) error {
+	if a.buffer == nil {
+		a.buffer = frame.Empty()
+		a.buffer.budget = 0
+	}
+	n := a.buffer.Len()
+	if _, err := a.buffer.TryConcat(frame); err != nil {
+		return err
+	}
+	if a.parts == nil {
+		a.size += rowsSize(a.buffer.lists, n, a.buffer.Len())
+		if a.size <= a.budget {
+			return nil
+		}
+		a.parts = make([]*spill, partitions)
+		for p := range a.parts {
+			s, err := newSpill(a.dir, a.dicts)
+			if err != nil {
+				return err
+			}
+			a.parts[p] = s
+		}
+		n = 0
+	}
+	lists, _ := a.buffer.gets(a.by)
+	lists = a.norm.lists(lists)
+	rows := make([][]int, partitions)
+	for i, m := n, a.buffer.Len(); i < m; i++ {
+		p := a.sum(i, lists) % partitions
+		rows[p] = append(rows[p], i)
+	}
+	// the partitions are opened one by one, to keep at most one file open.
+	for p, is := range rows {
+		if len(is) > 0 {
+			if err := a.write(a.parts[p], is); err != nil {
+				return err
+			}
+		}
+	}
+	a.buffer = a.buffer.Empty()
+	a.size = 0
+	return nil
+}
+
+// write appends the buffered rows to the spill.
+func (a *partition) write(s *spill, is []int) error {
+	w, err := s.writer()
+	if err != nil {
+		return err
+	}
+	for _, i := range is {
+		if err = w.writeRows(a.buffer.lists, i, i+1); err != nil {
+			break
+		}
+	}
+	if e := w.close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// do calls f with the rows of each partition.
+func (a *partition) do(depth int, f func(*Frame) error) error {
+	if a.parts == nil {
+		return f(a.buffer)
+	}
+	for _, s := range a.parts {
+		r, err := s.reader()
+		if err != nil {
+			return err
+		}
+		sub := &partition{
+			by:     a.by,
+			norm:   a.norm,
+			budget: a.budget,
+			dir:    a.dir,
+			dicts:  a.dicts,
+		}
+		if depth+1 >= maxDepth {
+			sub.budget = math.MaxInt64
+		}
+		err = a.read(r, sub)
+		if e := r.close(); err == nil {
+			err = e
+		}
+		if err == nil && sub.buffer != nil {
+			err = sub.do(depth+1, f)
+		}
+		sub.remove()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read reads the rows of a spill into the sub partition.
+func (a *partition) read(r *spillReader, sub *partition) error {
+	row := make([]Value, len(a.buffer.lists))
+	for {
+		frame := a.buffer.Empty()
+		for frame.Len() < spillChunk {
+			if err := r.readRow(row); err == io.EOF {
+				if frame.Len() == 0 {
+					return nil
+				}
+				return sub.add(frame)
+			} else if err != nil {
+				return err
+			}
+			for j, v := range row {
+				frame.lists[j] = append(frame.lists[j], v)
+			}
+		}
+		if err := sub.add(frame); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *partition) remove() {
+	for _, s := range a.parts {
+		s.remove()
+	}
+	a.parts = nil
+}
+
+// rowsSize returns the approximate memory size of rows i to j of lists in bytes.
+func rowsSize(lists []List, i, j int) int64 {
+	var n int64
+	for _, list := range lists {
+		for _, v := range list[i:j] {
+			n += sizeOf(v)
+		}
+	}
+	return n
+}
+
+// sortExternal sorts frame a by the keys with an external sort within the budget.
+func (a *Frame) sortExternal(keys []string) (*Frame, error) {
+	s := NewExternalSort(keys[0], keys[1:]...).Budget(a.budget)
+	b := a.Empty()
+	err := addChunks(a, s.Add)
+	if err == nil {
+		err = s.Do(func(frame *Frame) error {
+			_, err := b.TryConcat(frame)
+			return err
+		})
+	}
+	s.remove()
+	if err != nil {
+		return nil, err
+	}
+	a.lists, a.shared = b.lists, b.shared
+	return a, nil
+}
+
+// doExternal does group a with an external group within the budget of the frame.
+func (a *Group) doExternal() (*Frame, error) {
+	frame, err := TryNewFrame(a.names...)
+	if err != nil {
+		return nil, err
+	}
+	frame.workers = a.frame.workers
+	frame.budget = a.frame.budget
+	g := NewExternalGroup(a.by[0], a.by[1:]...).Budget(a.frame.budget)
+	g.root.norm = a.norm
+	// the keys are applied by the groups of the partitions.
+	n := len(a.by)
+	g.keys, g.names, g.funcs = a.keys[n:], a.names[n:], a.funcs[n:]
+	err = addChunks(a.frame, g.Add)
+	if err == nil {
+		err = g.Do(func(b *Frame) error {
+			_, err := frame.TryConcat(b)
+			return err
+		})
+	}
+	g.root.remove()
+	if err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// addChunks calls add with the slices of frame of at most spillChunk rows.
+func addChunks(frame *Frame, add func(*Frame) error) error {
+	for i, n := 0, frame.Len(); i < n; i += spillChunk {
+		j := i + spillChunk
+		if j > n {
+			j = n
+		}
+		if err := add(frame.Slice(i, j)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
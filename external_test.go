@@ -0,0 +1,190 @@
+package dt_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestExternalSort(t *testing.T) {
+	frame := mixed(2000)
+	dir := tempDir(t)
+	sort := dt.NewExternalSort("key", "value").Budget(1 << 9).Chunk(300).Dir(dir)
+	for i := 0; i < frame.Len(); i += 100 {
+		if err := sort.Add(frame.Slice(i, i+100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := frame.Empty()
+	err := sort.Do(func(f *dt.Frame) error {
+		if f.Len() > 300 {
+			t.Errorf("got a chunk of %d rows", f.Len())
+		}
+		got.Concat(f)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := frame.Copy(false).SortBy("key", "value")
+	if dttest.AssertFrameEqual(t, want, got) {
+		sameTypes(t, want.Get("value"), got.Get("value"))
+	}
+	assertEmptyDir(t, dir)
+}
+
+func TestExternalGroup(t *testing.T) {
+	frame := mixed(2000)
+	dir := tempDir(t)
+	group := dt.NewExternalGroup("key").Budget(1<<10).Dir(dir).
+		Apply("value", "count", dt.Count).
+		Apply("value", "first", dt.First).
+		Apply("value", "last", dt.Last)
+	for i := 0; i < frame.Len(); i += 100 {
+		if err := group.Add(frame.Slice(i, i+100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := dt.NewFrame("key", "count", "first", "last")
+	err := group.Do(func(f *dt.Frame) error {
+		got.Concat(f)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := frame.GroupBy("key").
+		Apply("value", "count", dt.Count).
+		Apply("value", "first", dt.First).
+		Apply("value", "last", dt.Last).
+		Do()
+	if dttest.AssertFrameEqual(t, want, got, dttest.IgnoreRowOrder()) {
+		want.SortBy("key")
+		got.SortBy("key")
+		sameTypes(t, want.Get("first"), got.Get("first"))
+		sameTypes(t, want.Get("last"), got.Get("last"))
+	}
+	assertEmptyDir(t, dir)
+}
+
+func TestExternalCategory(t *testing.T) {
+	levels := []string{"low", "mid", "high", "x"}
+	keys := make(dt.List, 2000)
+	for i := range keys {
+		keys[i] = dt.String(levels[i*7919%97%4])
+	}
+	frame := mixed(2000).Set("level", keys).AsOrdered("level", "low", "mid", "high")
+	dict := frame.Get("level")[0].(*dt.Category).Categories()
+
+	sort := dt.NewExternalSort("level", "key").Budget(1 << 9).Chunk(300).Dir(tempDir(t))
+	for i := 0; i < frame.Len(); i += 100 {
+		if err := sort.Add(frame.Slice(i, i+100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := frame.Empty()
+	if err := sort.Do(func(f *dt.Frame) error {
+		got.Concat(f)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := frame.Copy(false).SortBy("level", "key")
+	dttest.AssertFrameEqual(t, want, got)
+	sameDict(t, dict, got.Get("level"))
+
+	group := dt.NewExternalGroup("level").Budget(1<<10).Dir(tempDir(t)).
+		Apply("key", "count", dt.Count)
+	for i := 0; i < frame.Len(); i += 100 {
+		if err := group.Add(frame.Slice(i, i+100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got = dt.NewFrame("level", "count")
+	if err := group.Do(func(f *dt.Frame) error {
+		got.Concat(f)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want = frame.GroupBy("level").Apply("key", "count", dt.Count).Do()
+	dttest.AssertFrameEqual(t, want, got, dttest.IgnoreRowOrder())
+	sameDict(t, dict, got.Get("level"))
+}
+
+// sameDict checks that the values of the list are the categories of the dictionary or missing.
+func sameDict(t *testing.T, dict *dt.Categories, list dt.List) {
+	t.Helper()
+	for i, v := range list {
+		if dt.IsNull(v) {
+			continue
+		}
+		if c, ok := v.(*dt.Category); !ok || c.Categories() != dict {
+			t.Errorf("row %d: got %#v, want a category of the dictionary", i, v)
+			return
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	frame := mixed(2000)
+	want := frame.Copy(false).SortBy("value", "key")
+	got := frame.Copy(false).Budget(1<<10).SortBy("value", "key")
+	if dttest.AssertFrameEqual(t, want, got) {
+		sameTypes(t, want.Get("value"), got.Get("value"))
+	}
+
+	frame.Get("key")[0] = dt.String(" A")
+	frame.Get("key")[1] = dt.String("a")
+	group := func(frame *dt.Frame) *dt.Frame {
+		return frame.GroupBy("key").Trim(true).Fold(true).
+			Apply("value", "count", dt.Count).
+			Apply("value", "first", dt.First).
+			Do()
+	}
+	want = group(frame)
+	got = group(frame.Copy(false).Budget(1 << 10))
+	dttest.AssertFrameEqual(t, want, got, dttest.IgnoreRowOrder())
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "dt-external-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+// assertEmptyDir checks that the temporary files are removed.
+func assertEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+	fs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) > 0 {
+		t.Errorf("got %v temporary files left", len(fs))
+	}
+}
+
+// sameTypes checks that the values read back from the spills have the types written,
+// where nil and dt.Null are both null.
+func sameTypes(t *testing.T, want, got dt.List) {
+	t.Helper()
+	for i, v := range want {
+		if dt.IsNull(v) && dt.IsNull(got[i]) {
+			continue
+		}
+		if x, y := fmt.Sprintf("%T", v), fmt.Sprintf("%T", got[i]); x != y {
+			t.Errorf("row %d: want %s, got %s", i, x, y)
+		}
+	}
+}
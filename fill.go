@@ -0,0 +1,146 @@
+package dt
+
+import (
+	"math"
+)
+
+// FFill fills missing value with the previous value.
+// At most limit consecutive missing values are filled, if limit is positive.
+// NaN numbers are not missing, so they are kept and may fill the following missing values.
+func (a List) FFill(limit int) List {
+	return a.clone().ffill(limit)
+}
+
+func (a List) ffill(limit int) List {
+	var last Value
+	n := 0
+	for i, v := range a {
+		if !IsNull(v) {
+			last, n = v, 0
+		} else if last != nil && (limit <= 0 || n < limit) {
+			a[i] = last
+			n++
+		}
+	}
+	return a
+}
+
+// BFill fills missing value with the next value.
+// At most limit consecutive missing values are filled, if limit is positive.
+// NaN numbers are not missing, so they are kept and may fill the previous missing values.
+func (a List) BFill(limit int) List {
+	return a.clone().bfill(limit)
+}
+
+func (a List) bfill(limit int) List {
+	var next Value
+	n := 0
+	for i := len(a) - 1; i >= 0; i-- {
+		if v := a[i]; !IsNull(v) {
+			next, n = v, 0
+		} else if next != nil && (limit <= 0 || n < limit) {
+			a[i] = next
+			n++
+		}
+	}
+	return a
+}
+
+// Interpolate fills missing value between two numbers by linear interpolation on positions.
+// NaN numbers and values of other types are kept, and missing values next to them are not filled.
+func (a List) Interpolate() List {
+	return a.clone().interpolate(func(i int) float64 {
+		return float64(i)
+	})
+}
+
+// InterpolateBy fills missing value between two numbers by linear interpolation on x,
+// such as a time list for time-weighted interpolation.
+// NaN numbers and values of other types are kept, and missing values next to them are not filled.
+// Positions where x is NA are skipped.
+// It panics if the length of x is invalid.
+func (a List) InterpolateBy(x List) List {
+	l, err := a.TryInterpolateBy(x)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// TryInterpolateBy fills missing value between two numbers by linear interpolation on x,
+// or returns an error if the length of x is invalid.
+func (a List) TryInterpolateBy(x List) (List, error) {
+	if n, m := len(a), len(x); n != m {
+		return nil, &LengthError{
+			Expected: n,
+			Got:      m,
+		}
+	}
+	return a.clone().interpolate(func(i int) float64 {
+		if IsNA(x[i]) {
+			return math.NaN()
+		}
+		return x[i].Number()
+	}), nil
+}
+
+func (a List) interpolate(x func(int) float64) List {
+	k := -1
+	for i, v := range a {
+		if IsNull(v) || math.IsNaN(x(i)) {
+			continue
+		}
+		if !isNumeric(v) || IsNaN(v) {
+			k = -1
+			continue
+		}
+		if k >= 0 && i > k+1 {
+			x0, x1 := x(k), x(i)
+			y0, y1 := a[k].Number(), v.Number()
+			for j := k + 1; j < i; j++ {
+				if xj := x(j); IsNull(a[j]) && !math.IsNaN(xj) && x1 != x0 {
+					a[j] = Number(y0 + (y1-y0)*(xj-x0)/(x1-x0))
+				}
+			}
+		}
+		k = i
+	}
+	return a
+}
+
+// FFill fills missing value with the previous value in the key lists.
+// If no keys, all lists are filled.
+// The blanks of merged cells read by xlsx.Reader are missing, so they are filled with the merged values.
+// It panics if any key is not found.
+func (a *Frame) FFill(limit int, keys ...string) *Frame {
+	if _, err := a.TryFFill(limit, keys...); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryFFill fills missing value with the previous value in the key lists,
+// or returns an error if any key is not found.
+func (a *Frame) TryFFill(limit int, keys ...string) (*Frame, error) {
+	return a.fill(keys, func(l List) {
+		l.ffill(limit)
+	})
+}
+
+// BFill fills missing value with the next value in the key lists.
+// If no keys, all lists are filled.
+// It panics if any key is not found.
+func (a *Frame) BFill(limit int, keys ...string) *Frame {
+	if _, err := a.TryBFill(limit, keys...); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryBFill fills missing value with the next value in the key lists,
+// or returns an error if any key is not found.
+func (a *Frame) TryBFill(limit int, keys ...string) (*Frame, error) {
+	return a.fill(keys, func(l List) {
+		l.bfill(limit)
+	})
+}
@@ -0,0 +1,79 @@
+package dt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestFill(t *testing.T) {
+	nan := dt.Number(math.NaN())
+	l := dt.List{nil, dt.Int(1), nil, dt.Null{}, nil, nan, nil, dt.Int(2), nil}
+	assertList(t, dt.List{nil, dt.Int(1), dt.Int(1), dt.Int(1), dt.Int(1), nan, nan, dt.Int(2), dt.Int(2)}, l.FFill(0))
+	assertList(t, dt.List{nil, dt.Int(1), dt.Int(1), dt.Int(1), nil, nan, nan, dt.Int(2), dt.Int(2)}, l.FFill(2))
+	assertList(t, dt.List{dt.Int(1), dt.Int(1), nan, nan, nan, nan, dt.Int(2), dt.Int(2), nil}, l.BFill(0))
+	if l[2] != nil {
+		t.Errorf("list is changed: %v", l)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	nan := dt.Number(math.NaN())
+	l := dt.List{nil, dt.Int(1), nil, nil, dt.Number(4), nil, nan, nil, dt.Int(6), nil, dt.String("x"), nil, dt.Int(8)}
+	assertList(t, dt.List{nil, dt.Int(1), dt.Number(2), dt.Number(3), dt.Number(4), nil, nan, nil, dt.Int(6),
+		nil, dt.String("x"), nil, dt.Int(8)}, l.Interpolate())
+
+	x := dt.List{dt.Int(0), dt.Int(1), dt.Int(4), nil, dt.Int(10)}
+	l = dt.List{nil, dt.Int(0), nil, nil, dt.Number(9)}
+	assertList(t, dt.List{nil, dt.Int(0), dt.Number(3), nil, dt.Number(9)}, l.InterpolateBy(x))
+	if _, err := l.TryInterpolateBy(x[1:]); err == nil {
+		t.Error("list is interpolated by x of invalid length")
+	}
+}
+
+func TestFrameFill(t *testing.T) {
+	frame := dttest.Frame(`
+		g | x  | y
+		a | 1  | 1
+		a | NA | NA
+		b | NA | NA
+		b | 2  | 2
+	`)
+	want := dttest.Frame(`
+		g | x | y
+		a | 1 | 1
+		a | 1 | NA
+		b | 1 | NA
+		b | 2 | 2
+	`)
+	dttest.AssertFrameEqual(t, want, frame.Copy(false).FFill(0, "x"))
+	want = dttest.Frame(`
+		g | x  | y
+		a | 1  | 1
+		a | 2  | 2
+		b | 2  | 2
+		b | 2  | 2
+	`)
+	dttest.AssertFrameEqual(t, want, frame.Copy(false).BFill(0))
+	if _, err := frame.TryFFill(0, "z"); err == nil {
+		t.Error("missing key is filled")
+	}
+
+	got := frame.GroupBy("g").Transform("x", func(l dt.List) dt.List {
+		return l.FFill(0)
+	})
+	assertList(t, dt.List{dt.Int(1), dt.Int(1), nil, dt.Int(2)}, got)
+
+	frame = dttest.Frame(`
+		g  | x
+		a  | 1
+		NA | 2
+		a  | NA
+	`)
+	got = frame.GroupBy("g").NA(dt.NADrop).Transform("x", func(l dt.List) dt.List {
+		return l.FFill(0)
+	})
+	assertList(t, dt.List{dt.Int(1), nil, dt.Int(1)}, got)
+}
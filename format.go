@@ -0,0 +1,344 @@
+package dt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Style is the table style of the formatter.
+type Style int
+
+// The table styles.
+const (
+	Plain Style = iota
+	Box
+)
+
+// Formatter is the frame formatter.
+type Formatter struct {
+	head    int
+	tail    int
+	width   int
+	null    string
+	style   Style
+	formats map[string]string
+}
+
+// NewFormatter creates a new formatter.
+func NewFormatter() *Formatter {
+	return &Formatter{
+		head:    10,
+		tail:    10,
+		width:   30,
+		null:    "NA",
+		formats: make(map[string]string),
+	}
+}
+
+// Head is the number of head rows shown, 10 by default.
+// If it is negative, all rows are shown.
+func (a *Formatter) Head(o int) *Formatter {
+	a.head = o
+	return a
+}
+
+// Tail is the number of tail rows shown, 10 by default.
+// If it is negative, all rows are shown.
+func (a *Formatter) Tail(o int) *Formatter {
+	a.tail = o
+	return a
+}
+
+// Width is the max column width, 30 by default.
+// If it is 0, columns are never cut.
+func (a *Formatter) Width(o int) *Formatter {
+	if o < 0 {
+		panic("dt: invalid width: " + strconv.Itoa(o))
+	}
+	a.width = o
+	return a
+}
+
+// Null is the token of missing values, "NA" by default.
+func (a *Formatter) Null(o string) *Formatter {
+	a.null = o
+	return a
+}
+
+// Style is the table style, Plain by default.
+func (a *Formatter) Style(o Style) *Formatter {
+	a.style = o
+	return a
+}
+
+// Format sets the fmt verb of numbers in the key column, such as "%.2f" or "%d".
+// Decimal values are formatted exactly by Decimal.Format.
+// Other numbers are formatted as float64 by float verbs, as int64 rounded half away from zero by %d,
+// and by their String methods by other verbs.
+func (a *Formatter) Format(key string, verb string) *Formatter {
+	a.formats[key] = verb
+	return a
+}
+
+// String formats the frame as string.
+func (a *Formatter) String(frame *Frame) string {
+	buf := new(bytes.Buffer)
+	a.Write(frame, buf)
+	return buf.String()
+}
+
+// Write writes the formatted frame to the io.Writer.
+func (a *Formatter) Write(frame *Frame, w io.Writer) error {
+	keys, lists := frame.Keys(), frame.Lists()
+	m, n := len(lists), frame.Len()
+	if m == 0 {
+		return nil
+	}
+
+	rows := make([]int, 0, n)
+	omit := 0
+	if a.head < 0 || a.tail < 0 || n <= a.head+a.tail {
+		for i := 0; i < n; i++ {
+			rows = append(rows, i)
+		}
+	} else {
+		for i := 0; i < a.head; i++ {
+			rows = append(rows, i)
+		}
+		for i := n - a.tail; i < n; i++ {
+			rows = append(rows, i)
+		}
+		omit = n - a.head - a.tail
+	}
+
+	cells := make([][]string, m)
+	rights := make([]bool, m)
+	widths := make([]int, m)
+	for j, list := range lists {
+		cells[j] = make([]string, len(rows)+1)
+		cells[j][0] = a.cut(keys[j])
+		numbers := 0
+		for k, i := range rows {
+			v := list[i]
+			if isNumeric(v) {
+				numbers++
+			}
+			cells[j][k+1] = a.cut(a.text(keys[j], v))
+		}
+		rights[j] = numbers > 0 && numbers == countNotNull(list, rows)
+		for _, s := range cells[j] {
+			if x := textWidth(s); x > widths[j] {
+				widths[j] = x
+			}
+		}
+	}
+
+	omitted := fmt.Sprintf("… %v rows", omit)
+	if omit == 1 {
+		omitted = "… 1 row"
+	}
+	if a.style == Box && omit > 0 {
+		// the last column is widened to hold the marker in the box.
+		total := m - 1
+		for _, x := range widths {
+			total += x + 2
+		}
+		if x := textWidth(omitted) + 2 - total; x > 0 {
+			widths[m-1] += x
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	line := func(k int) {
+		for j := range cells {
+			s := cells[j][k]
+			pad := strings.Repeat(" ", widths[j]-textWidth(s))
+			if a.style == Box {
+				buf.WriteString("│ ")
+			} else if j > 0 {
+				buf.WriteString("  ")
+			}
+			if rights[j] {
+				buf.WriteString(pad + s)
+			} else if j < m-1 || a.style == Box {
+				buf.WriteString(s + pad)
+			} else {
+				buf.WriteString(s)
+			}
+			if a.style == Box {
+				buf.WriteString(" ")
+			}
+		}
+		if a.style == Box {
+			buf.WriteString("│")
+		}
+		buf.WriteString("\n")
+	}
+	border := func(left, mid, right string) {
+		buf.WriteString(left)
+		for j, x := range widths {
+			if j > 0 {
+				buf.WriteString(mid)
+			}
+			buf.WriteString(strings.Repeat("─", x+2))
+		}
+		buf.WriteString(right + "\n")
+	}
+	marker := func() {
+		s := omitted
+		if a.style != Box {
+			buf.WriteString(s + "\n")
+			return
+		}
+		total := m - 1
+		for _, x := range widths {
+			total += x + 2
+		}
+		border("├", "┴", "┤")
+		buf.WriteString("│ " + s)
+		if x := total - 1 - textWidth(s); x > 0 {
+			buf.WriteString(strings.Repeat(" ", x))
+		}
+		buf.WriteString("│\n")
+		if a.tail > 0 {
+			border("├", "┬", "┤")
+		} else {
+			border("└", "─", "┘")
+		}
+	}
+
+	if a.style == Box {
+		border("┌", "┬", "┐")
+	}
+	line(0)
+	if a.style == Box {
+		border("├", "┼", "┤")
+	}
+	for k := range rows {
+		if omit > 0 && k == a.head {
+			marker()
+		}
+		line(k + 1)
+	}
+	if omit > 0 && a.tail == 0 {
+		marker()
+	}
+	if a.style == Box && (omit == 0 || a.tail > 0) {
+		border("└", "┴", "┘")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func (a *Formatter) text(key string, v Value) string {
+	if IsNull(v) {
+		return a.null
+	}
+	if verb, ok := a.formats[key]; ok && isNumeric(v) {
+		return fmt.Sprintf(verb, operand(v, verb))
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, v.String())
+}
+
+func (a *Formatter) cut(s string) string {
+	if a.width == 0 || textWidth(s) <= a.width {
+		return s
+	}
+	x := 0
+	for k, r := range s {
+		if x += runeWidth(r); x > a.width-1 {
+			return s[:k] + "…"
+		}
+	}
+	return s
+}
+
+// operand returns the operand of numeric value v for the fmt verb.
+func operand(v Value, verb string) interface{} {
+	if x, ok := v.(Decimal); ok {
+		return x
+	}
+	switch c := conversion(verb); {
+	case c == 'd':
+		if x, ok := v.(Int); ok {
+			return int64(x)
+		}
+		if x := math.Round(v.Number()); x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x)
+		}
+		return v.Number()
+	case strings.ContainsRune("eEfFgG", c):
+		return v.Number()
+	}
+	return v
+}
+
+// conversion returns the conversion character of the first verb in the format.
+func conversion(format string) rune {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+		for ; i < len(format); i++ {
+			if c := format[i]; !strings.ContainsRune("+-# 0123456789.[]*", rune(c)) {
+				return rune(c)
+			}
+		}
+	}
+	return 0
+}
+
+func isNumeric(v Value) bool {
+	switch v.(type) {
+	case Number, Int, Decimal:
+		return true
+	default:
+		return false
+	}
+}
+
+func countNotNull(l List, rows []int) int {
+	n := 0
+	for _, i := range rows {
+		if !IsNull(l[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func textWidth(s string) int {
+	x := 0
+	for _, r := range s {
+		x += runeWidth(r)
+	}
+	return x
+}
+
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,103 @@
+package dt_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ofunc/dt"
+)
+
+func TestFormat(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("x", dt.List{dt.Int(1), dt.Number(2.5), dt.NewDecimal(125, 2), nil}).
+		Add("s", dt.List{dt.String("a"), dt.String("b\tc"), dt.String("d"), dt.String("e")})
+	got := dt.NewFormatter().Format("x", "%d").String(frame)
+	want := " x  s\n" +
+		" 1  a\n" +
+		" 3  b c\n" +
+		" 1  d\n" +
+		"NA  e\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	got = dt.NewFormatter().Format("x", "%.1f").String(frame)
+	want = "  x  s\n" +
+		"1.0  a\n" +
+		"2.5  b c\n" +
+		"1.3  d\n" +
+		" NA  e\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatRows(t *testing.T) {
+	l := make(dt.List, 5)
+	for i := range l {
+		l[i] = dt.Int(i)
+	}
+	frame := dt.NewFrame().Add("x", l)
+
+	got := dt.NewFormatter().Head(2).Tail(2).String(frame)
+	want := "x\n0\n1\n… 1 row\n3\n4\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	got = dt.NewFormatter().Head(1).Tail(0).String(frame)
+	want = "x\n0\n… 4 rows\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	want = "x\n0\n1\n2\n3\n4\n"
+	for _, f := range []*dt.Formatter{
+		dt.NewFormatter().Head(-1).Tail(1),
+		dt.NewFormatter().Head(1).Tail(-1),
+	} {
+		if got := f.String(frame); got != want {
+			t.Errorf("got\n%s\nwant\n%s", got, want)
+		}
+	}
+
+	got = dt.NewFormatter().Head(1).Tail(1).Style(dt.Box).String(frame)
+	want = "┌──────────┐\n" +
+		"│        x │\n" +
+		"├──────────┤\n" +
+		"│        0 │\n" +
+		"├──────────┤\n" +
+		"│ … 3 rows │\n" +
+		"├──────────┤\n" +
+		"│        4 │\n" +
+		"└──────────┘\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDecimalFormat(t *testing.T) {
+	for _, c := range []struct {
+		format string
+		value  dt.Decimal
+		want   string
+	}{
+		{"%v", dt.NewDecimal(125, 2), "1.25"},
+		{"%s", dt.NewDecimal(-125, 2), "-1.25"},
+		{"%d", dt.NewDecimal(125, 2), "1"},
+		{"%d", dt.NewDecimal(-150, 2), "-2"},
+		{"%05d", dt.NewDecimal(-125, 1), "-0013"},
+		{"%.1f", dt.NewDecimal(125, 2), "1.3"},
+		{"%.3f", dt.NewDecimal(125, 2), "1.250"},
+		{"%f", dt.NewDecimal(125, 2), "1.25"},
+		{"%+.2f", dt.NewDecimal(5, 1), "+0.50"},
+		{"%8.2f|", dt.NewDecimal(-5, 1), "   -0.50|"},
+		{"%08.2f", dt.NewDecimal(-5, 1), "-0000.50"},
+		{"%-6.1f|", dt.NewDecimal(5, 1), "0.5   |"},
+		{"%.2e", dt.NewDecimal(125, 2), "1.25e+00"},
+		{"%.1f", dt.NewDecimal(math.MaxInt64, 0), "9223372036854775808.0"},
+	} {
+		if got := fmt.Sprintf(c.format, c.value); got != c.want {
+			t.Errorf("got %q of %q, want %q", got, c.format, c.want)
+		}
+	}
+}
@@ -1,32 +1,48 @@
 package dt
 
 import (
-	"bytes"
-	"errors"
-	"fmt"
 	"sort"
 )
 
 // Frame is the frame data structure.
+//
+// Frames are copy-on-write: lists shared with other frames, such as by Copy, Slice or Pick,
+// or set by users, are cloned before the first mutation by the methods of frame.
+// The lists returned by Get and Lists are not cloned, and mutating them in place
+// affects all frames sharing them.
 type Frame struct {
-	index map[string]int
-	lists []List
+	index   map[string]int
+	lists   []List
+	shared  []bool
+	workers int
+	budget  int64
 }
 
 // NewFrame creates a new frame.
+// It panics if the keys are duplicate.
 func NewFrame(keys ...string) *Frame {
+	frame, err := TryNewFrame(keys...)
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryNewFrame creates a new frame, or returns an error if the keys are duplicate.
+func TryNewFrame(keys ...string) (*Frame, error) {
 	n := len(keys)
 	index := make(map[string]int, n)
 	for j, key := range keys {
 		if _, ok := index[key]; ok {
-			panic("dt: key already exists: " + key)
+			return nil, keyExists(key)
 		}
 		index[key] = j
 	}
 	return &Frame{
-		index: index,
-		lists: make([]List, n),
-	}
+		index:  index,
+		lists:  make([]List, n),
+		shared: make([]bool, n),
+	}, nil
 }
 
 // Empty returns a empty frame like frame a.
@@ -36,20 +52,44 @@ func (a *Frame) Empty() *Frame {
 		index[key] = j
 	}
 	return &Frame{
-		index: index,
-		lists: make([]List, len(a.lists)),
+		index:   index,
+		lists:   make([]List, len(a.lists)),
+		shared:  make([]bool, len(a.lists)),
+		workers: a.workers,
+		budget:  a.budget,
 	}
 }
 
+// Budget is the approximate memory budget in bytes of SortBy and Group.Do,
+// which is kept by the frames made from frame a like Workers.
+// If the rows exceed it, they are sorted by ExternalSort,
+// or grouped by ExternalGroup in order of the partitions instead of their first rows,
+// by spilling to temporary files in os.TempDir(). By default, there is no budget.
+func (a *Frame) Budget(o int64) *Frame {
+	a.budget = o
+	return a
+}
+
+// Workers is the number of workers of Map, MapTo, Filter, GroupBy and Group.Do,
+// which is kept by the frames made from frame a, such as by Copy, Slice, Pick, Join or GroupBy.
+// If it is negative, the number of CPUs is used. By default, it runs serially.
+// Functions passed to the parallel operations must be safe for concurrent use.
+func (a *Frame) Workers(o int) *Frame {
+	a.workers = o
+	return a
+}
+
 // Copy makes a copy of frame a.
+// A shallow copy shares the lists until they are mutated.
 func (a *Frame) Copy(deep bool) *Frame {
 	b := a.Empty()
-	copy(b.lists, a.lists)
-	if deep {
-		for j, l := range b.lists {
-			t := make(List, len(l))
-			copy(t, l)
-			b.lists[j] = t
+	for j, l := range a.lists {
+		if deep {
+			b.lists[j] = l.clone()
+		} else {
+			b.lists[j] = l
+			a.shared[j] = true
+			b.shared[j] = true
 		}
 	}
 	return b
@@ -91,43 +131,72 @@ func (a *Frame) Has(keys ...string) bool {
 func (a *Frame) Check(keys ...string) error {
 	for _, key := range keys {
 		if _, ok := a.index[key]; !ok {
-			return errors.New("dt: key not found: " + key)
+			return keyNotFound(key)
 		}
 	}
 	return nil
 }
 
 // Get gets the list by key.
+// It panics if the key is not found.
 func (a *Frame) Get(key string) List {
+	list, err := a.TryGet(key)
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
+
+// TryGet gets the list by key, or returns an error if the key is not found.
+func (a *Frame) TryGet(key string) (List, error) {
 	if j, ok := a.index[key]; ok {
-		return a.lists[j]
+		return a.lists[j], nil
 	}
-	panic("dt: key not found: " + key)
+	return nil, keyNotFound(key)
 }
 
 // Set sets the list by key.
+// If frame a has no rows, the other lists are filled with nil to the length of list.
+// It panics if the length of list is invalid.
 func (a *Frame) Set(key string, list List) *Frame {
-	a.check(list)
-	if j, ok := a.index[key]; ok {
-		a.lists[j] = list
-		return a
+	if _, err := a.TrySet(key, list); err != nil {
+		panic(err)
 	}
-	a.index[key] = len(a.lists)
-	a.lists = append(a.lists, list)
 	return a
 }
 
+// TrySet sets the list by key, or returns an error if the length of list is invalid.
+func (a *Frame) TrySet(key string, list List) (*Frame, error) {
+	if err := a.check(list); err != nil {
+		return nil, err
+	}
+	a.set(key, list, true)
+	return a, nil
+}
+
 // Add adds the list with key.
+// If frame a has no rows, the other lists are filled with nil to the length of list.
+// It panics if the key already exists or the length of list is invalid.
 func (a *Frame) Add(key string, list List) *Frame {
-	if _, ok := a.index[key]; ok {
-		panic("dt: key already exists: " + key)
+	if _, err := a.TryAdd(key, list); err != nil {
+		panic(err)
 	}
-	a.check(list)
-	a.index[key] = len(a.lists)
-	a.lists = append(a.lists, list)
 	return a
 }
 
+// TryAdd adds the list with key,
+// or returns an error if the key already exists or the length of list is invalid.
+func (a *Frame) TryAdd(key string, list List) (*Frame, error) {
+	if _, ok := a.index[key]; ok {
+		return nil, keyExists(key)
+	}
+	if err := a.check(list); err != nil {
+		return nil, err
+	}
+	a.set(key, list, true)
+	return a, nil
+}
+
 // Del deletes the list by keys.
 func (a *Frame) Del(keys ...string) *Frame {
 	for _, key := range keys {
@@ -137,29 +206,53 @@ func (a *Frame) Del(keys ...string) *Frame {
 }
 
 // Rename renames the key.
+// It panics if the old key is not found or the new key already exists.
 func (a *Frame) Rename(old, new string) *Frame {
-	if j, ok := a.index[old]; ok {
-		if _, ok := a.index[new]; ok {
-			panic("dt: key already exists: " + new)
-		}
-		delete(a.index, old)
-		a.index[new] = j
-	} else {
-		panic("dt: key not found: " + old)
+	if _, err := a.TryRename(old, new); err != nil {
+		panic(err)
 	}
 	return a
 }
 
+// TryRename renames the key,
+// or returns an error if the old key is not found or the new key already exists.
+func (a *Frame) TryRename(old, new string) (*Frame, error) {
+	j, ok := a.index[old]
+	if !ok {
+		return nil, keyNotFound(old)
+	}
+	if _, ok := a.index[new]; ok {
+		return nil, keyExists(new)
+	}
+	delete(a.index, old)
+	a.index[new] = j
+	return a, nil
+}
+
 // Pick picks some lists and returns a new frame,
+// It panics if any key is not found.
 func (a *Frame) Pick(key string, keys ...string) *Frame {
-	b := NewFrame(key)
-	b.lists[0] = a.Get(key)
-	for _, key := range keys {
-		b.Set(key, a.Get(key))
+	b, err := a.TryPick(key, keys...)
+	if err != nil {
+		panic(err)
 	}
 	return b
 }
 
+// TryPick picks some lists and returns a new frame, or returns an error if any key is not found.
+func (a *Frame) TryPick(key string, keys ...string) (*Frame, error) {
+	if err := a.Check(append([]string{key}, keys...)...); err != nil {
+		return nil, err
+	}
+	b := NewFrame().Workers(a.workers)
+	for _, key := range append([]string{key}, keys...) {
+		j := a.index[key]
+		a.shared[j] = true
+		b.set(key, a.lists[j], true)
+	}
+	return b, nil
+}
+
 // Iter returns a iter of frame a.
 func (a *Frame) Iter() *Iter {
 	return &Iter{
@@ -168,33 +261,70 @@ func (a *Frame) Iter() *Iter {
 	}
 }
 
-// Slice gets the slice of frame a.
+// Slice gets the slice of frame a, which shares the lists until they are mutated.
+// Negative indexes count from the end.
+// It panics if the indexes are out of range.
 func (a *Frame) Slice(i, j int) *Frame {
+	b, err := a.TrySlice(i, j)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TrySlice gets the slice of frame a, or returns an error if the indexes are out of range.
+func (a *Frame) TrySlice(i, j int) (*Frame, error) {
 	n := a.Len()
-	if i < 0 {
-		i += n
+	x, y := i, j
+	if x < 0 {
+		x += n
+	}
+	if y < 0 {
+		y += n
+	}
+	if x < 0 || x > n {
+		return nil, &IndexError{
+			Index: i,
+			Len:   n,
+		}
 	}
-	if j < 0 {
-		j += n
+	if y < x || y > n {
+		return nil, &IndexError{
+			Index: j,
+			Len:   n,
+		}
 	}
 	b := a.Copy(false)
 	for k, list := range b.lists {
-		b.lists[k] = list[i:j]
+		b.lists[k] = list[x:y:y]
 	}
-	return b
+	return b, nil
 }
 
 // Concat concats frame a with b.
+// It panics if any key of frame a is not found in b.
 func (a *Frame) Concat(b *Frame) *Frame {
-	for key, j := range a.index {
-		a.lists[j] = append(a.lists[j], b.Get(key)...)
+	if _, err := a.TryConcat(b); err != nil {
+		panic(err)
 	}
 	return a
 }
 
+// TryConcat concats frame a with b, or returns an error if any key of frame a is not found in b.
+func (a *Frame) TryConcat(b *Frame) (*Frame, error) {
+	if err := b.Check(a.Keys()...); err != nil {
+		return nil, err
+	}
+	for key, j := range a.index {
+		a.lists[j] = append(a.own(j), b.Get(key)...)
+	}
+	return a, nil
+}
+
 // Append appends x to frames a.
 func (a *Frame) Append(rs ...Record) *Frame {
 	for key, j := range a.index {
+		a.own(j)
 		for _, r := range rs {
 			a.lists[j] = append(a.lists[j], r.Value(key))
 		}
@@ -204,6 +334,7 @@ func (a *Frame) Append(rs ...Record) *Frame {
 
 // Sort sorts frame a by function f.
 func (a *Frame) Sort(f func(Record, Record) bool) *Frame {
+	a.ownAll()
 	sort.Sort(sorter{
 		frame: a,
 		cmp:   f,
@@ -211,32 +342,93 @@ func (a *Frame) Sort(f func(Record, Record) bool) *Frame {
 	return a
 }
 
+// SortBy sorts frame a by the keys in ascending order of Compare.
+// The sort is stable, and spills to temporary files if the rows exceed the budget.
+// It panics if any key is not found or spilling fails.
+func (a *Frame) SortBy(key string, keys ...string) *Frame {
+	if _, err := a.TrySortBy(key, keys...); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TrySortBy sorts frame a by the keys in ascending order,
+// or returns an error if any key is not found or spilling fails.
+func (a *Frame) TrySortBy(key string, keys ...string) (*Frame, error) {
+	keys = append([]string{key}, keys...)
+	if err := a.Check(keys...); err != nil {
+		return nil, err
+	}
+	if a.budget > 0 && rowsSize(a.lists, 0, a.Len()) > a.budget {
+		return a.sortExternal(keys)
+	}
+	a.ownAll()
+	lists, _ := a.gets(keys)
+	sort.Stable(sorter{
+		frame: a,
+		cmp: func(x, y Record) bool {
+			i, j := x.(record).index, y.(record).index
+			for _, l := range lists {
+				if c := Compare(l[i], l[j]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		},
+	})
+	return a, nil
+}
+
 // Map maps frame a to list by function f.
 func (a *Frame) Map(f func(Record) Value) List {
-	list := make(List, 0, a.Len())
-	for iter := a.Iter(); iter.Next(); {
-		list = append(list, f(iter.Record()))
-	}
+	list := make(List, a.Len())
+	bounds := split(len(list), grain, a.workers)
+	parallel(len(bounds)-1, func(c int) {
+		for i := bounds[c]; i < bounds[c+1]; i++ {
+			list[i] = f(record{
+				frame: a,
+				index: i,
+			})
+		}
+	})
 	return list
 }
 
 // MapTo maps frame a to the key list.
 func (a *Frame) MapTo(key string, f func(Record) Value) *Frame {
-	list := a.Map(f)
-	a.Set(key, list)
+	a.set(key, a.Map(f), false)
 	return a
 }
 
 // Filter filters the frame with function f.
 func (a *Frame) Filter(f func(Record) bool) *Frame {
-	b := a.Empty()
-	for iter := a.Iter(); iter.Next(); {
-		r := iter.Record().(record)
-		if f(r) {
-			for j, l := range b.lists {
-				b.lists[j] = append(l, a.lists[j][r.index])
+	bounds := split(a.Len(), grain, a.workers)
+	parts := make([][]int, len(bounds)-1)
+	parallel(len(parts), func(c int) {
+		for i := bounds[c]; i < bounds[c+1]; i++ {
+			if f(record{
+				frame: a,
+				index: i,
+			}) {
+				parts[c] = append(parts[c], i)
 			}
 		}
+	})
+	var is []int
+	for _, p := range parts {
+		is = append(is, p...)
+	}
+	return a.take(is)
+}
+
+func (a *Frame) take(is []int) *Frame {
+	b := a.Empty()
+	for j, l := range a.lists {
+		t := make(List, len(is))
+		for k, i := range is {
+			t[k] = l[i]
+		}
+		b.lists[j] = t
 	}
 	return b
 }
@@ -257,16 +449,21 @@ func (a *Frame) DropNA(keys ...string) *Frame {
 }
 
 // FillNA fills NA value with v.
+// It panics if any key is not found.
 func (a *Frame) FillNA(value Value, keys ...string) *Frame {
-	if len(keys) == 0 {
-		keys = a.Keys()
-	}
-	for _, key := range keys {
-		a.Get(key).FillNA(value)
+	if _, err := a.TryFillNA(value, keys...); err != nil {
+		panic(err)
 	}
 	return a
 }
 
+// TryFillNA fills NA value with v, or returns an error if any key is not found.
+func (a *Frame) TryFillNA(value Value, keys ...string) (*Frame, error) {
+	return a.fill(keys, func(l List) {
+		l.fillNA(value)
+	})
+}
+
 // Join joins frame a and b.
 func (a *Frame) Join(b *Frame, key string, keys ...string) *Join {
 	return &Join{
@@ -277,60 +474,105 @@ func (a *Frame) Join(b *Frame, key string, keys ...string) *Join {
 }
 
 // GroupBy groups records by keys.
+// It panics if any key is not found.
 func (a *Frame) GroupBy(key string, keys ...string) *Group {
-	m := len(keys)
-	keys = append(keys, key)
-	keys[0], keys[m] = keys[m], keys[0]
-
-	lists := make([]List, len(keys))
-	for j, key := range keys {
-		lists[j] = a.Get(key)
+	g, err := a.TryGroupBy(key, keys...)
+	if err != nil {
+		panic(err)
 	}
-	data := make(map[string]([]int))
-	for i, n := 0, a.Len(); i < n; i++ {
-		k := makeKey(i, lists)
-		data[k] = append(data[k], i)
+	return g
+}
+
+// TryGroupBy groups records by keys, or returns an error if any key is not found.
+func (a *Frame) TryGroupBy(key string, keys ...string) (*Group, error) {
+	keys = append([]string{key}, keys...)
+	if err := a.Check(keys...); err != nil {
+		return nil, err
 	}
 	g := &Group{
 		frame: a,
-		data:  data,
+		by:    keys,
 	}
 	for _, key := range keys {
 		g.Apply(key, key, First)
 	}
-	return g
+	return g, nil
 }
 
-// String shows frame a as string.
+// String shows frame a as string, by the default formatter.
 func (a *Frame) String() string {
-	buf := new(bytes.Buffer)
-	m := len(a.lists) - 1
-	for j, key := range a.Keys() {
-		fmt.Fprint(buf, key)
-		if j == m {
-			fmt.Fprintln(buf)
-		} else {
-			fmt.Fprint(buf, "\t")
+	return NewFormatter().String(a)
+}
+
+// check checks the length of list, which may be any if frame a has no rows.
+func (a *Frame) check(list List) error {
+	if n, m := a.Len(), len(list); n > 0 && n != m {
+		return &LengthError{
+			Expected: n,
+			Got:      m,
 		}
 	}
-	n := a.Len()
-	for i := 0; i < n; i++ {
-		for j, l := range a.lists {
-			fmt.Fprint(buf, l[i])
-			if j == m {
-				fmt.Fprintln(buf)
-			} else {
-				fmt.Fprint(buf, "\t")
-			}
+	return nil
+}
+
+// set sets the list by key, which may be shared with others.
+// If frame a has no rows, the other lists are filled with nil to the length of list.
+func (a *Frame) set(key string, list List, shared bool) {
+	if n := len(list); n > 0 && a.Len() == 0 {
+		for j := range a.lists {
+			a.lists[j] = make(List, n)
+			a.shared[j] = false
 		}
 	}
-	return buf.String()
+	if j, ok := a.index[key]; ok {
+		a.lists[j] = list
+		a.shared[j] = shared
+		return
+	}
+	a.index[key] = len(a.lists)
+	a.lists = append(a.lists, list)
+	a.shared = append(a.shared, shared)
+}
+
+// own clones the j-th list if it is shared, and returns it.
+func (a *Frame) own(j int) List {
+	if a.shared[j] {
+		a.lists[j] = a.lists[j].clone()
+		a.shared[j] = false
+	}
+	return a.lists[j]
+}
+
+func (a *Frame) ownAll() {
+	for j := range a.lists {
+		a.own(j)
+	}
 }
 
-func (a *Frame) check(list List) {
-	if n, m := a.Len(), len(list); n != m {
-		panic(fmt.Sprintf("dt: invalid list length, expected %v, got %v", n, m))
+// fill fills the key lists by function f in place, or all lists if no keys.
+func (a *Frame) fill(keys []string, f func(List)) (*Frame, error) {
+	if len(keys) == 0 {
+		keys = a.Keys()
+	}
+	if err := a.Check(keys...); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		f(a.own(a.index[key]))
+	}
+	return a, nil
+}
+
+func (a *Frame) gets(keys []string) ([]List, error) {
+	lists := make([]List, len(keys))
+	for j, key := range keys {
+		list, err := a.TryGet(key)
+		if err != nil {
+			return nil, err
+		}
+		lists[j] = list
 	}
+	return lists, nil
 }
 
 func (a *Frame) del(key string) {
@@ -338,6 +580,8 @@ func (a *Frame) del(key string) {
 		delete(a.index, key)
 		copy(a.lists[j:], a.lists[j+1:])
 		a.lists = a.lists[:len(a.lists)-1]
+		copy(a.shared[j:], a.shared[j+1:])
+		a.shared = a.shared[:len(a.shared)-1]
 		for key, k := range a.index {
 			if k > j {
 				a.index[key] = k - 1
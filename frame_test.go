@@ -0,0 +1,118 @@
+package dt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestSetNoRows(t *testing.T) {
+	got := dt.NewFrame("a", "b").Set("b", dt.List{dt.Int(1), dt.Int(2)})
+	want := dttest.Frame(`
+		a  | b
+		NA | 1
+		NA | 2
+	`)
+	dttest.AssertFrameEqual(t, want, got)
+
+	if _, err := got.TrySet("a", dt.List{dt.Int(1)}); err == nil {
+		t.Error("a list of invalid length is set")
+	}
+}
+
+func TestTryError(t *testing.T) {
+	frame := dt.NewFrame().Add("a", dt.List{dt.Int(1), dt.Int(2)})
+	if b, err := frame.TryRename("x", "y"); b != nil || err == nil {
+		t.Errorf("got %v, %v, want nil and an error", b, err)
+	}
+	if b, err := frame.TrySet("a", dt.List{dt.Int(1)}); b != nil || err == nil {
+		t.Errorf("got %v, %v, want nil and an error", b, err)
+	}
+	if b, err := frame.TryMoveBefore("a", "x"); b != nil || err == nil {
+		t.Errorf("got %v, %v, want nil and an error", b, err)
+	}
+	if b, err := frame.TryRenameMap(map[string]string{"x": "y"}); b != nil || err == nil {
+		t.Errorf("got %v, %v, want nil and an error", b, err)
+	}
+	if b, err := frame.TryFillNA(dt.Int(0), "x"); b != nil || err == nil {
+		t.Errorf("got %v, %v, want nil and an error", b, err)
+	}
+
+	dict := dt.NewCategories(false, "a", "b")
+	if c, err := dict.TryCode(2); c != nil || !errors.Is(err, dt.ErrIndexOutOfRange) {
+		t.Errorf("got %v, %v, want nil and ErrIndexOutOfRange", c, err)
+	}
+	if c, err := dict.TryCode(1); err != nil || c.String() != "b" {
+		t.Errorf("got %v, %v, want b", c, err)
+	}
+
+	cut := dt.List{dt.Int(1)}.Cut(0, 2).Labels(dt.List{dt.String("x"), dt.String("y")})
+	if _, err := cut.TryDo(); !errors.Is(err, dt.ErrLengthMismatch) {
+		t.Errorf("got %v, want ErrLengthMismatch", err)
+	}
+	if lower, upper, err := cut.TryBounds(); err != nil || lower[0] != dt.Number(0) || upper[0] != dt.Number(2) {
+		t.Errorf("got %v, %v, %v, want [0], [2]", lower, upper, err)
+	}
+	cut = dt.List{dt.Int(1)}.Cut(2, 0)
+	if _, _, err := cut.TryBounds(); err == nil {
+		t.Error("bounds of invalid edges are returned")
+	}
+}
+
+const table = `
+	id | name  | amount
+	3  | pear  | 2
+	1  | apple | 1.50
+	2  | NA    | 0.25
+`
+
+func TestCopyOnWrite(t *testing.T) {
+	tests := map[string]func(*dt.Frame) *dt.Frame{
+		"Copy": func(a *dt.Frame) *dt.Frame {
+			return a.Copy(false)
+		},
+		"Slice": func(a *dt.Frame) *dt.Frame {
+			return a.Slice(0, 2)
+		},
+		"Pick": func(a *dt.Frame) *dt.Frame {
+			return a.Pick("id", "name", "amount")
+		},
+	}
+	mutations := map[string]func(*dt.Frame){
+		"SortBy": func(b *dt.Frame) {
+			b.SortBy("id")
+		},
+		"FillNA": func(b *dt.Frame) {
+			b.FillNA(dt.String("?"))
+		},
+		"FFill": func(b *dt.Frame) {
+			b.FFill(0)
+		},
+		"Append": func(b *dt.Frame) {
+			it := b.Iter()
+			it.Next()
+			b.Append(it.Record())
+		},
+		"Rename": func(b *dt.Frame) {
+			b.Rename("name", "fruit")
+		},
+	}
+	for name, f := range tests {
+		for mname, mutate := range mutations {
+			t.Run(name+"/"+mname, func(t *testing.T) {
+				a := dttest.Frame(table)
+				mutate(f(a))
+				dttest.AssertFrameEqual(t, dttest.Frame(table), a)
+			})
+		}
+	}
+}
+
+func TestCopyOnWriteOriginal(t *testing.T) {
+	a := dttest.Frame(table)
+	b := a.Copy(false)
+	a.SortBy("id").FillNA(dt.String("?"))
+	dttest.AssertFrameEqual(t, dttest.Frame(table), b)
+}
@@ -3,7 +3,8 @@ package dt
 // Group is a group data structure.
 type Group struct {
 	frame *Frame
-	data  map[string]([]int)
+	by    []string
+	norm  normalizer
 	keys  []string
 	names []string
 	funcs [](func(List) Value)
@@ -18,17 +19,190 @@ func (a *Group) Apply(key string, name string, f func(List) Value) *Group {
 }
 
 // Do does the group.
+// The rows are grouped by ExternalGroup if they exceed the budget of the frame.
+// It panics if any key is not found, the names are duplicate or spilling fails.
 func (a *Group) Do() *Frame {
-	frame := NewFrame(a.names...)
-	for _, is := range a.data {
-		for j, key := range a.keys {
-			list := a.frame.Get(key)
-			l := make(List, len(is))
-			for k, i := range is {
-				l[k] = list[i]
+	frame, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the group,
+// or returns an error if any key is not found, the names are duplicate or spilling fails.
+func (a *Group) TryDo() (*Frame, error) {
+	if b := a.frame.budget; b > 0 && rowsSize(a.frame.lists, 0, a.frame.Len()) > b {
+		return a.doExternal()
+	}
+	frame, err := TryNewFrame(a.names...)
+	if err != nil {
+		return nil, err
+	}
+	frame.workers = a.frame.workers
+	lists, err := a.frame.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := a.groups()
+	if err != nil {
+		return nil, err
+	}
+	for j := range lists {
+		frame.lists[j] = make(List, len(groups))
+	}
+	bounds := split(len(groups), 1, a.frame.workers)
+	parallel(len(bounds)-1, func(c int) {
+		for g := bounds[c]; g < bounds[c+1]; g++ {
+			is := groups[g]
+			for j, list := range lists {
+				l := make(List, len(is))
+				for k, i := range is {
+					l[k] = list[i]
+				}
+				frame.lists[j][g] = a.funcs[j](l)
+			}
+		}
+	})
+	return frame, nil
+}
+
+// Transform transforms the key list by function f in each group,
+// and returns the list of the results in the original row order, such as:
+//
+//	frame.Set(key, frame.GroupBy(k).Transform(key, func(l List) List { return l.FFill(0) }))
+//
+// The rows in no group, such as the rows of NA keys dropped by NADrop, are nil in the list.
+// It panics if the key is not found or f returns a list of invalid length.
+func (a *Group) Transform(key string, f func(List) List) List {
+	l, err := a.TryTransform(key, f)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// TryTransform transforms the key list by function f in each group,
+// or returns an error if the key is not found or f returns a list of invalid length.
+func (a *Group) TryTransform(key string, f func(List) List) (List, error) {
+	list, err := a.frame.TryGet(key)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := a.groups()
+	if err != nil {
+		return nil, err
+	}
+	r := make(List, len(list))
+	for _, is := range groups {
+		l := make(List, len(is))
+		for k, i := range is {
+			l[k] = list[i]
+		}
+		l = f(l)
+		if n, m := len(is), len(l); n != m {
+			return nil, &LengthError{
+				Expected: n,
+				Got:      m,
 			}
-			frame.lists[j] = append(frame.lists[j], a.funcs[j](l))
+		}
+		for k, i := range is {
+			r[i] = l[k]
 		}
 	}
-	return frame
+	return r, nil
+}
+
+// groups returns the row indexes of groups, in order of their first rows.
+func (a *Group) groups() ([][]int, error) {
+	lists, err := a.frame.gets(a.by)
+	if err != nil {
+		return nil, err
+	}
+	lists = a.norm.lists(lists)
+	bounds := split(a.frame.Len(), grain, a.frame.workers)
+	parts := make([][][]int, len(bounds)-1)
+	keyed := make([][]bool, len(parts))
+	parallel(len(parts), func(c int) {
+		parts[c], keyed[c] = a.partial(lists, bounds[c], bounds[c+1])
+	})
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	var groups [][]int
+	var ids []int
+	t := newTable(lists)
+	for c, part := range parts {
+		for k, is := range part {
+			if !keyed[c][k] {
+				groups = append(groups, is)
+				continue
+			}
+			g, ok := t.insert(is[0])
+			if ok {
+				ids = append(ids, len(groups))
+				groups = append(groups, nil)
+			}
+			groups[ids[g]] = append(groups[ids[g]], is...)
+		}
+	}
+	return groups, nil
+}
+
+// partial returns the groups of rows i to j, in order of their first rows,
+// and whether each group has a key in the hash table.
+func (a *Group) partial(lists []List, i, j int) ([][]int, []bool) {
+	var groups [][]int
+	var keyed []bool
+	if len(lists) == 1 {
+		if dict, ok := categories(lists[0][i:j]); ok {
+			return a.partialCodes(lists[0], dict, i, j)
+		}
+	}
+	var ids []int
+	t := newTable(lists)
+	for ; i < j; i++ {
+		if a.norm.na != NAMatch && a.norm.isNA(i, lists) {
+			if a.norm.na == NASeparate {
+				groups = append(groups, []int{i})
+				keyed = append(keyed, false)
+			}
+			continue
+		}
+		g, ok := t.insert(i)
+		if ok {
+			ids = append(ids, len(groups))
+			groups = append(groups, nil)
+			keyed = append(keyed, true)
+		}
+		groups[ids[g]] = append(groups[ids[g]], i)
+	}
+	return groups, keyed
+}
+
+// partialCodes is partial of a list of categories, which groups rows by the codes.
+func (a *Group) partialCodes(list List, dict *Categories, i, j int) ([][]int, []bool) {
+	var groups [][]int
+	var keyed []bool
+	ids := make([]int, dict.Len()+1)
+	for ; i < j; i++ {
+		k := 0
+		if c, ok := list[i].(*Category); ok {
+			k = c.code + 1
+		} else if a.norm.na != NAMatch {
+			if a.norm.na == NASeparate {
+				groups = append(groups, []int{i})
+				keyed = append(keyed, false)
+			}
+			continue
+		}
+		if ids[k] == 0 {
+			groups = append(groups, nil)
+			keyed = append(keyed, true)
+			ids[k] = len(groups)
+		}
+		g := ids[k] - 1
+		groups[g] = append(groups[g], i)
+	}
+	return groups, keyed
 }
@@ -0,0 +1,148 @@
+package dt
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"time"
+)
+
+// table is a hash table of the distinct keys of rows.
+// Equal keys by Compare have equal hashes, and collisions are resolved by Equal.
+type table struct {
+	hasher
+	lists []List
+	heads map[uint64]int
+	next  []int
+	rows  []int
+}
+
+// newTable creates a hash table of the rows of the key lists.
+func newTable(lists []List) *table {
+	return &table{
+		lists: lists,
+		heads: make(map[uint64]int),
+	}
+}
+
+// insert inserts row i of the table lists,
+// and returns the id of its key and whether the key is new.
+func (a *table) insert(i int) (int, bool) {
+	h := a.sum(i, a.lists)
+	g, ok := a.heads[h]
+	for ok && g >= 0 {
+		if a.equal(a.rows[g], i, a.lists) {
+			return g, false
+		}
+		g = a.next[g]
+	}
+	g = len(a.rows)
+	if k, ok := a.heads[h]; ok {
+		a.next = append(a.next, k)
+	} else {
+		a.next = append(a.next, -1)
+	}
+	a.heads[h] = g
+	a.rows = append(a.rows, i)
+	return g, true
+}
+
+// find finds row i of lists in the table, and returns the id of its key or -1.
+func (a *table) find(i int, lists []List) int {
+	g, ok := a.heads[a.sum(i, lists)]
+	for ok && g >= 0 {
+		if a.equal(a.rows[g], i, lists) {
+			return g
+		}
+		g = a.next[g]
+	}
+	return -1
+}
+
+// equal checks if row r of the table lists equals row i of lists.
+func (a *table) equal(r, i int, lists []List) bool {
+	for j, list := range lists {
+		if !equal(a.lists[j][r], list[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasher hashes rows consistently with Equal.
+type hasher struct {
+	hash maphash.Hash
+	buf  [8]byte
+}
+
+// sum returns the hash of row i of lists.
+func (a *hasher) sum(i int, lists []List) uint64 {
+	a.hash.Reset()
+	for _, list := range lists {
+		a.write(list[i])
+	}
+	return a.hash.Sum64()
+}
+
+func (a *hasher) write(v Value) {
+	r := rank(v)
+	a.hash.WriteByte(byte(r))
+	switch r {
+	case rankBool:
+		a.hash.WriteByte(byte(v.Number()))
+	case rankNumber:
+		switch x := v.(type) {
+		case Int:
+			a.writeUint64(uint64(x))
+			return
+		case Decimal:
+			if x.coef%pow10[x.scale] == 0 {
+				a.writeUint64(uint64(x.coef / pow10[x.scale]))
+				return
+			}
+		default:
+			if d, ok := integral(v.Number()); ok {
+				a.writeUint64(uint64(d.coef))
+				return
+			}
+		}
+		a.hash.WriteByte(1)
+		a.writeUint64(math.Float64bits(v.Number()))
+	case rankString:
+		s := v.String()
+		a.writeUint64(uint64(len(s)))
+		a.hash.WriteString(s)
+	case rankTime:
+		t := time.Time(v.(Time))
+		a.writeUint64(uint64(t.Unix()))
+		a.writeUint64(uint64(t.Nanosecond()))
+	}
+}
+
+func (a *hasher) writeUint64(x uint64) {
+	binary.LittleEndian.PutUint64(a.buf[:], x)
+	a.hash.Write(a.buf[:])
+}
+
+// equal is Equal with fast paths of the same types.
+func equal(a, b Value) bool {
+	switch x := a.(type) {
+	case String:
+		if y, ok := b.(String); ok {
+			return x == y
+		}
+	case Int:
+		if y, ok := b.(Int); ok {
+			return x == y
+		}
+	case Number:
+		if y, ok := b.(Number); ok && x == y {
+			return true
+		}
+	case *Category:
+		if y, ok := b.(*Category); ok && x.dict == y.dict {
+			return x == y
+		}
+	}
+	return Equal(a, b)
+}
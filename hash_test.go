@@ -0,0 +1,69 @@
+package dt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+// numberKeys are the keys of mixed number types, where values equal by dt.Equal are in a row.
+var numberKeys = dt.List{
+	dt.Int(1), dt.Number(1), dt.NewDecimal(100, 2),
+	dt.Number(1.5), dt.NewDecimal(15, 1),
+	dt.Number(math.NaN()), dt.Number(math.NaN()),
+	dt.Number(math.Copysign(0, -1)), dt.Int(0), dt.NewDecimal(0, 3),
+	nil, dt.Null{},
+}
+
+func TestUnique(t *testing.T) {
+	got := numberKeys.Unique()
+	want := dt.List{dt.Int(1), dt.Number(1.5), dt.Number(math.NaN()), dt.Number(math.Copysign(0, -1)), nil}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i, v := range got {
+		if !dt.Equal(v, want[i]) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGroupByNumbers(t *testing.T) {
+	frame := dt.NewFrame().Add("key", numberKeys).Add("x", dt.List{
+		dt.Int(1), dt.Int(1), dt.Int(1),
+		dt.Int(2), dt.Int(2),
+		dt.Int(3), dt.Int(3),
+		dt.Int(4), dt.Int(4), dt.Int(4),
+		dt.Int(5), dt.Int(5),
+	})
+	got := frame.GroupBy("key").Apply("x", "count", dt.Count).Apply("x", "sum", dt.Sum).Do()
+	want := dttest.Frame(`
+		key | count | sum
+		1   | 3     | 3
+		1.5 | 2     | 4
+		NaN | 2     | 6
+		0   | 3     | 12
+		NA  | 2     | 10
+	`)
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestJoinNumbers(t *testing.T) {
+	left := dt.NewFrame().Add("key", dt.List{
+		dt.NewDecimal(100, 2), dt.Int(0), dt.Number(1.5), dt.Number(math.NaN()), dt.Int(2),
+	})
+	right := dttest.Frame(`
+		key  | name
+		1    | one
+		1.50 | one and a half
+		-0.0 | zero
+	`)
+	right.Get("key")[2] = dt.Number(math.Copysign(0, -1))
+	got := left.Join(right, "key").Do("")
+	want := dt.NewFrame().Add("key", left.Get("key")).Add("name", dt.List{
+		dt.String("one"), dt.String("zero"), dt.String("one and a half"), nil, nil,
+	})
+	dttest.AssertFrameEqual(t, want, got)
+}
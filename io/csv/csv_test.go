@@ -0,0 +1,161 @@
+package csv_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+	"github.com/ofunc/dt/io/csv"
+)
+
+// roundTrip writes the frame by the writer, and reads it back by the reader.
+func roundTrip(t *testing.T, w *csv.Writer, r *csv.Reader, frame *dt.Frame) (*dt.Frame, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := w.Write(frame, &buf); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	got, err := r.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, text
+}
+
+func TestBoolTime(t *testing.T) {
+	loc := time.FixedZone("", 8*60*60)
+	want := dt.NewFrame().
+		Add("ok", dt.List{dt.Bool(true), dt.Bool(false)}).
+		Add("on", dt.List{
+			dt.Time(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+			dt.Time(time.Date(2020, 1, 2, 3, 4, 5, 0, loc)),
+		})
+	got, text := roundTrip(t, csv.NewWriter(), csv.NewReader(), want)
+	dttest.AssertFrameEqual(t, want, got)
+	if w := "ok,on\ntrue,2020-01-02\nfalse,2020-01-02T03:04:05+08:00\n"; text != w {
+		t.Errorf("got %q, want %q", text, w)
+	}
+}
+
+func TestExact(t *testing.T) {
+	want := dt.NewFrame().
+		Add("int", dt.List{dt.Int(1234567890123456789)}).
+		Add("decimal", dt.List{dt.NewDecimal(150, 2)})
+	got, text := roundTrip(t, csv.NewWriter(), csv.NewReader(), want)
+	dttest.AssertFrameEqual(t, want, got)
+	if w := "int,decimal\n1234567890123456789,1.50\n"; text != w {
+		t.Errorf("got %q, want %q", text, w)
+	}
+}
+
+func TestNA(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("x", dt.List{nil, dt.Null{}, dt.Number(math.NaN()), dt.Number(1)})
+	got, text := roundTrip(t, csv.NewWriter().Null("NA").NaN("NaN"), csv.NewReader().NA("NA", "-"), frame)
+	if w := "x\nNA\nNA\nNaN\n1\n"; text != w {
+		t.Errorf("got %q, want %q", text, w)
+	}
+	want := dt.List{dt.Null{}, dt.Null{}, dt.Number(math.NaN()), dt.Int(1)}
+	for i, v := range got.Get("x") {
+		if dt.IsNull(v) != dt.IsNull(want[i]) || dt.IsNaN(v) != dt.IsNaN(want[i]) || !dt.Equal(v, want[i]) {
+			t.Errorf("row %d: got %#v, want %#v", i, v, want[i])
+		}
+	}
+
+	got, err := csv.NewReader().NA("-").Read(strings.NewReader("x,y\n - ,\n1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the tokens are missing, so the empty string is not.
+	dttest.AssertFrameEqual(t, dt.NewFrame().
+		Add("x", dt.List{nil, dt.Int(1)}).
+		Add("y", dt.List{dt.String(""), dt.Int(2)}), got)
+}
+
+func TestReadChunks(t *testing.T) {
+	text := "x,y\n1,a\n2,b\n,\n3,c\n4,d\n5,e\n,\n,\n6,f\n,\n,\n"
+	r := csv.NewReader().Tail(1)
+	want, err := r.Read(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want.Len() != 8 {
+		t.Fatalf("got %v rows, want 8", want.Len())
+	}
+
+	var lens []int
+	var chunks []*dt.Frame
+	err = r.ReadChunks(strings.NewReader(text), 3, func(frame *dt.Frame) error {
+		lens = append(lens, frame.Len())
+		chunks = append(chunks, frame)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the trailing empty records and the tail are dropped, but the empty records between are kept.
+	if len(lens) != 3 || lens[0] != 3 || lens[1] != 3 || lens[2] != 2 {
+		t.Fatalf("got chunk lengths %v, want [3 3 2]", lens)
+	}
+	got := chunks[0]
+	for _, chunk := range chunks[1:] {
+		got = got.Concat(chunk)
+	}
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestHeader(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("x", dt.List{dt.Int(1)}).
+		Add("y", dt.List{dt.String("a")})
+	var buf bytes.Buffer
+	if err := csv.NewWriter().Write(frame, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := csv.NewWriter().Header(false).Write(frame, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "x,y\n1,a\n1,a\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadChunksError(t *testing.T) {
+	f := func(*dt.Frame) error {
+		return nil
+	}
+	if err := csv.NewReader().ReadChunks(strings.NewReader(""), 2, f); err == nil {
+		t.Error("empty data is read")
+	}
+	if err := csv.NewReader().Tail(2).ReadChunks(strings.NewReader("x\n1\n,\n"), 2, f); err == nil {
+		t.Error("a tail longer than the records is accepted")
+	}
+
+	// a frame without rows is passed if there are no records.
+	count := 0
+	err := csv.NewReader().ReadChunks(strings.NewReader("x,y\n"), 2, func(frame *dt.Frame) error {
+		if count++; frame.Len() != 0 || len(frame.Keys()) != 2 {
+			t.Errorf("got %v, want an empty frame of x and y", frame)
+		}
+		return nil
+	})
+	if err != nil || count != 1 {
+		t.Errorf("got %v and %d calls, want 1 call", err, count)
+	}
+
+	stop := errors.New("stop")
+	count = 0
+	err = csv.NewReader().ReadChunks(strings.NewReader("x\n1\n2\n3\n"), 1, func(*dt.Frame) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("got %v and %d calls, want the error of the first call", err, count)
+	}
+}
@@ -24,12 +24,15 @@ type Reader struct {
 	lazyQuotes       bool
 	trimLeadingSpace bool
 	suffix           string
+	na               map[string]bool
 	transformer      transform.Transformer
 }
 
 // NewReader creates a new reader.
 func NewReader() *Reader {
-	return new(Reader)
+	return &Reader{
+		na: util.NA([]string{""}),
+	}
 }
 
 // Drop is the drop option.
@@ -80,6 +83,13 @@ func (a *Reader) Suffix(o string) *Reader {
 	return a
 }
 
+// NA is the input tokens of missing values, which are read as dt.Null.
+// The tokens match the values with white spaces trimmed, and only "" is the token by default.
+func (a *Reader) NA(tokens ...string) *Reader {
+	a.na = util.NA(tokens)
+	return a
+}
+
 // Transformer is the transformer quotes option.
 func (a *Reader) Transformer(o transform.Transformer) *Reader {
 	a.transformer = o
@@ -98,21 +108,87 @@ func (a *Reader) ReadFile(name string) (*dt.Frame, error) {
 
 // Read reads a frame from the io.Reader.
 func (a *Reader) Read(r io.Reader) (*dt.Frame, error) {
-	var err error
-	r, err = a.reader(r)
+	cr, err := a.csvReader(r)
 	if err != nil {
 		return nil, err
 	}
-	cr := csv.NewReader(r)
-	if a.comma != 0 {
-		cr.Comma = a.comma
-	}
-	cr.Comment = a.comment
-	cr.LazyQuotes = a.lazyQuotes
-	cr.TrimLeadingSpace = a.trimLeadingSpace
 	return a.ReadCSV(cr)
 }
 
+// ReadFileChunks reads frames of at most n rows from the file, and calls f with them in order.
+func (a *Reader) ReadFileChunks(name string, n int, f func(*dt.Frame) error) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return a.ReadChunks(file, n, f)
+}
+
+// ReadChunks reads frames of at most n rows from the io.Reader, and calls f with them in order.
+// Unlike Read, the records are streamed, so the data need not fit in memory.
+// If there are no rows, f is called once with an empty frame.
+func (a *Reader) ReadChunks(r io.Reader, n int, f func(*dt.Frame) error) error {
+	if n <= 0 {
+		panic("dt/io/csv: invalid chunk: " + strconv.Itoa(n))
+	}
+	cr, err := a.csvReader(r)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < a.drop; i++ {
+		if _, err := cr.Read(); err == io.EOF {
+			return errors.New("dt/io/csv.Reader: empty data")
+		} else if err != nil {
+			return err
+		}
+	}
+	head, err := cr.Read()
+	if err == io.EOF {
+		return errors.New("dt/io/csv.Reader: empty data")
+	} else if err != nil {
+		return err
+	}
+	keys := util.Keys(head, a.suffix)
+	if _, err := dt.TryNewFrame(keys...); err != nil {
+		return err
+	}
+
+	// rs holds back the tail records and the trailing empty records, whose number is empty.
+	var rs [][]string
+	empty, count := 0, 0
+	for {
+		r, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		rs = append(rs, r)
+		if isEmpty(r) {
+			empty++
+		} else {
+			empty = 0
+		}
+		for len(rs)-a.tail-empty >= n {
+			if err := f(a.frame(keys, rs[:n])); err != nil {
+				return err
+			}
+			count++
+			rs = rs[n:]
+		}
+	}
+	rs = rs[:len(rs)-empty]
+	if a.tail > len(rs) {
+		return errors.New("dt/io/csv.Reader: empty data")
+	}
+	rs = rs[:len(rs)-a.tail]
+	if len(rs) > 0 || count == 0 {
+		return f(a.frame(keys, rs))
+	}
+	return nil
+}
+
 // ReadCSV reads a frame from the csv.Reader.
 func (a *Reader) ReadCSV(cr *csv.Reader) (*dt.Frame, error) {
 	rs, err := cr.ReadAll()
@@ -124,21 +200,47 @@ func (a *Reader) ReadCSV(cr *csv.Reader) (*dt.Frame, error) {
 
 // ReadRecords reads a frame from the records.
 func (a *Reader) ReadRecords(rs [][]string) (*dt.Frame, error) {
+	if a.drop >= len(rs) {
+		return nil, errors.New("dt/io/csv.Reader: empty data")
+	}
 	rs = rs[a.drop:]
 	rs = cutEmpty(rs)
-	rs = rs[:len(rs)-a.tail]
-	if len(rs) < 1 {
+	if a.tail >= len(rs) {
 		return nil, errors.New("dt/io/csv.Reader: empty data")
 	}
+	rs = rs[:len(rs)-a.tail]
+
+	keys := util.Keys(rs[0], a.suffix)
+	if _, err := dt.TryNewFrame(keys...); err != nil {
+		return nil, err
+	}
+	return a.frame(keys, rs[1:]), nil
+}
 
-	frame := dt.NewFrame(util.Keys(rs[0], a.suffix)...)
+func (a *Reader) frame(keys []string, rs [][]string) *dt.Frame {
+	frame := dt.NewFrame(keys...)
 	lists := frame.Lists()
-	for _, r := range rs[1:] {
+	for _, r := range rs {
 		for i, l := range lists {
-			lists[i] = append(l, value(r, i))
+			lists[i] = append(l, a.value(r, i))
 		}
 	}
-	return frame, nil
+	return frame
+}
+
+func (a *Reader) csvReader(r io.Reader) (*csv.Reader, error) {
+	r, err := a.reader(r)
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(r)
+	if a.comma != 0 {
+		cr.Comma = a.comma
+	}
+	cr.Comment = a.comment
+	cr.LazyQuotes = a.lazyQuotes
+	cr.TrimLeadingSpace = a.trimLeadingSpace
+	return cr, nil
 }
 
 func (a *Reader) reader(r io.Reader) (io.Reader, error) {
@@ -158,10 +260,13 @@ func (a *Reader) reader(r io.Reader) (io.Reader, error) {
 	return br, nil
 }
 
-func value(r []string, i int) dt.Value {
+func (a *Reader) value(r []string, i int) dt.Value {
 	if i >= len(r) {
 		return nil
 	}
+	if a.na[strings.TrimSpace(r[i])] {
+		return dt.Null{}
+	}
 	return util.Value(r[i])
 }
 
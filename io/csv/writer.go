@@ -11,6 +11,9 @@ import (
 
 // Writer is the CSV writer.
 type Writer struct {
+	header      bool
+	null        string
+	nan         string
 	comma       rune
 	useCRLF     bool
 	transformer transform.Transformer
@@ -18,7 +21,29 @@ type Writer struct {
 
 // NewWriter creates a new writer.
 func NewWriter() *Writer {
-	return new(Writer)
+	return &Writer{
+		header: true,
+		nan:    "NaN",
+	}
+}
+
+// Header is whether to write the header, true by default.
+// It can be disabled to append frames to a written file.
+func (a *Writer) Header(o bool) *Writer {
+	a.header = o
+	return a
+}
+
+// Null is the output token of missing values, "" by default.
+func (a *Writer) Null(o string) *Writer {
+	a.null = o
+	return a
+}
+
+// NaN is the output token of NaN numbers, "NaN" by default.
+func (a *Writer) NaN(o string) *Writer {
+	a.nan = o
+	return a
 }
 
 // Comma is the comma option.
@@ -63,15 +88,19 @@ func (a *Writer) Write(frame *dt.Frame, w io.Writer) error {
 	}
 	cw.UseCRLF = a.useCRLF
 
-	if err := cw.Write(frame.Keys()); err != nil {
-		return err
+	if a.header {
+		if err := cw.Write(frame.Keys()); err != nil {
+			return err
+		}
 	}
 	n, lists := frame.Len(), frame.Lists()
 	r := make([]string, len(lists))
 	for i := 0; i < n; i++ {
 		for j, l := range lists {
-			if v := l[i]; v == nil {
-				r[j] = ""
+			if v := l[i]; dt.IsNull(v) {
+				r[j] = a.null
+			} else if dt.IsNaN(v) {
+				r[j] = a.nan
 			} else {
 				r[j] = v.String()
 			}
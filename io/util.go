@@ -4,11 +4,23 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ofunc/dt"
 )
 
-var regDigits = regexp.MustCompile(`^\d+$`)
+var regInt = regexp.MustCompile(`^[+-]?\d+$`)
+
+var regDecimal = regexp.MustCompile(`^[+-]?(\d+\.\d*|\.\d+)$`)
+
+var regDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+var layouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339Nano,
+}
 
 // Keys rename duplicate keys with suffix.
 func Keys(keys []string, suffix string) []string {
@@ -22,12 +34,55 @@ func Keys(keys []string, suffix string) []string {
 }
 
 // Value returns the dt.Value by the value.
+// Integers are dt.Int, and kept as dt.String if out of range.
+// Plain decimals are dt.Decimal, and other numbers are dt.Number.
 func Value(value string) dt.Value {
 	x := strings.TrimSpace(value)
-	if len(x) < 16 || !regDigits.MatchString(x) {
-		if v, err := strconv.ParseFloat(x, 64); err == nil {
-			return dt.Number(v)
+	if regInt.MatchString(x) {
+		if v, err := strconv.ParseInt(x, 10, 64); err == nil {
+			return dt.Int(v)
 		}
+		return dt.String(value)
+	}
+	if regDecimal.MatchString(x) {
+		if v, err := dt.ParseDecimal(x); err == nil {
+			return v
+		}
+	}
+	if v, err := strconv.ParseFloat(x, 64); err == nil {
+		return dt.Number(v)
+	}
+	switch x {
+	case "true", "TRUE", "True":
+		return dt.Bool(true)
+	case "false", "FALSE", "False":
+		return dt.Bool(false)
+	}
+	if v, ok := Time(x); ok {
+		return dt.Time(v)
 	}
 	return dt.String(value)
 }
+
+// NA returns the set of the tokens of missing values.
+func NA(tokens []string) map[string]bool {
+	m := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		m[t] = true
+	}
+	return m
+}
+
+// Time parses the value as an ISO 8601 date or time.
+func Time(value string) (time.Time, bool) {
+	x := strings.TrimSpace(value)
+	if !regDate.MatchString(x) {
+		return time.Time{}, false
+	}
+	for _, layout := range layouts {
+		if v, err := time.Parse(layout, x); err == nil {
+			return v, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,39 @@
+package io_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+	util "github.com/ofunc/dt/io"
+)
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		s    string
+		want dt.Value
+	}{
+		{"123", dt.Int(123)},
+		{"-9007199254740993", dt.Int(-9007199254740993)},
+		{"99999999999999999999", dt.String("99999999999999999999")},
+		{"1.50", dt.NewDecimal(150, 2)},
+		{".5", dt.NewDecimal(5, 1)},
+		{"1e3", dt.Number(1000)},
+		{"1.0000000000000000001", dt.Number(1)},
+		{"true", dt.Bool(true)},
+		{"FALSE", dt.Bool(false)},
+		{"2020-01-02", dt.Time(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))},
+		{"apple", dt.String("apple")},
+	}
+	for _, tt := range tests {
+		got := util.Value(tt.s)
+		if fmt.Sprintf("%T", got) != fmt.Sprintf("%T", tt.want) || !dt.Equal(got, tt.want) {
+			t.Errorf("Value(%q) = %#v, want %#v", tt.s, got, tt.want)
+		}
+	}
+	if v := util.Value("NaN"); !math.IsNaN(v.Number()) {
+		t.Errorf("Value(%q) = %#v, want NaN", "NaN", v)
+	}
+}
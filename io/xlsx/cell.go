@@ -4,5 +4,6 @@ package xlsx
 type Cell struct {
 	Ref   string `xml:"r,attr"`
 	Type  string `xml:"t,attr"`
+	Style string `xml:"s,attr,omitempty"`
 	Value string `xml:"v"`
 }
@@ -1,6 +1,7 @@
 package xlsx
 
 import (
+	"errors"
 	"strings"
 )
 
@@ -10,9 +11,13 @@ type CellIter struct {
 	j     int
 	c     int
 	cells []*Cell
+	err   error
 }
 
 func (a *CellIter) next() bool {
+	if a.err != nil {
+		return false
+	}
 	a.i++
 	if a.i <= a.c {
 		return true
@@ -21,11 +26,15 @@ func (a *CellIter) next() bool {
 	if a.j >= len(a.cells) {
 		return false
 	}
-	_, a.c = CellIndex(strings.ToUpper(a.cells[a.j].Ref))
-	if a.i > a.c {
-		panic("dt/io/xlsx: invalid xlsx file")
+	ref := a.cells[a.j].Ref
+	_, a.c, a.err = CellIndex(strings.ToUpper(ref))
+	if a.err == nil && a.i > a.c {
+		a.err = &FormatError{
+			Ref: ref,
+			Err: errors.New("cell out of order"),
+		}
 	}
-	return true
+	return a.err == nil
 }
 
 func (a *CellIter) cell() *Cell {
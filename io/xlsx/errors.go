@@ -0,0 +1,68 @@
+package xlsx
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidXLSX is the error of an invalid xlsx file.
+	ErrInvalidXLSX = errors.New("dt/io/xlsx: invalid xlsx file")
+	// ErrSheetNotFound is the error of a sheet not found.
+	ErrSheetNotFound = errors.New("dt/io/xlsx: sheet not found")
+)
+
+// FormatError is the error of an invalid xlsx file with the location.
+type FormatError struct {
+	Part string
+	Ref  string
+	Err  error
+}
+
+// Error returns the error message.
+func (e *FormatError) Error() string {
+	s := ErrInvalidXLSX.Error()
+	if e.Part != "" {
+		s += ": " + e.Part
+	}
+	if e.Ref != "" {
+		s += ": " + e.Ref
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// Unwrap returns the underlying error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrInvalidXLSX.
+func (e *FormatError) Is(target error) bool {
+	return target == ErrInvalidXLSX
+}
+
+// SheetError is the error of a sheet.
+type SheetError struct {
+	Sheet string
+	Err   error
+}
+
+// Error returns the error message.
+func (e *SheetError) Error() string {
+	return e.Err.Error() + ": " + e.Sheet
+}
+
+// Unwrap returns the underlying error.
+func (e *SheetError) Unwrap() error {
+	return e.Err
+}
+
+func withPart(err error, part string) error {
+	var e *FormatError
+	if errors.As(err, &e) && e.Part == "" {
+		e.Part = part
+	}
+	return err
+}
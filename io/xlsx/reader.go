@@ -2,7 +2,6 @@ package xlsx
 
 import (
 	"archive/zip"
-	"fmt"
 	"io"
 	"strconv"
 	"strings"
@@ -19,12 +18,14 @@ type Reader struct {
 	sep    string
 	sheet  string
 	suffix string
+	na     map[string]bool
 }
 
 // NewReader creates a new reader.
 func NewReader() *Reader {
 	return &Reader{
 		head: 1,
+		na:   util.NA([]string{""}),
 	}
 }
 
@@ -73,6 +74,14 @@ func (a *Reader) Suffix(o string) *Reader {
 	return a
 }
 
+// NA is the input tokens of missing values, which are read as dt.Null.
+// The tokens match the strings with white spaces trimmed, and only "" is the token by default.
+// Besides, the error cells of #NUM! and #DIV/0! are read as NaN, and the other error cells as dt.Null.
+func (a *Reader) NA(tokens ...string) *Reader {
+	a.na = util.NA(tokens)
+	return a
+}
+
 // ReadFile reads a frame from the file.
 func (a *Reader) ReadFile(name string) (*dt.Frame, error) {
 	workbook, err := OpenFile(name)
@@ -101,14 +110,18 @@ func (a *Reader) ReadZip(zr *zip.Reader) (*dt.Frame, error) {
 }
 
 // ReadWorkbook reads a frame from the workbook.
-func (a *Reader) ReadWorkbook(workbook *Workbook) (frame *dt.Frame, err error) {
-	defer func() {
-		if e := recover(); e != nil {
-			err = fmt.Errorf("%v", e)
-		}
-	}()
+func (a *Reader) ReadWorkbook(workbook *Workbook) (*dt.Frame, error) {
+	sheet, err := workbook.sheet(a.sheet)
+	if err != nil {
+		return nil, err
+	}
+	data, err := sheet.data()
+	if err != nil {
+		return nil, err
+	}
+	part := "xl/" + sheet.target
 
-	rowiter := workbook.sheet(a.sheet).data().rowIter()
+	rowiter := data.rowIter()
 	for i := 0; i < a.drop; i++ {
 		if !rowiter.next() {
 			break
@@ -122,18 +135,28 @@ func (a *Reader) ReadWorkbook(workbook *Workbook) (frame *dt.Frame, err error) {
 		}
 		row := rowiter.row()
 		if row != nil {
-			for celliter := row.cellIter(); celliter.next(); {
-				if v := workbook.value(celliter.cell()); v != nil {
+			celliter := row.cellIter()
+			for celliter.next() {
+				if v := workbook.value(celliter.cell()); !dt.IsNull(v) {
 					hs[i] = append(hs[i], v.String())
 				} else {
 					hs[i] = append(hs[i], "")
 				}
 			}
+			if celliter.err != nil {
+				return nil, withPart(celliter.err, part)
+			}
 		}
 	}
+	if rowiter.err != nil {
+		return nil, withPart(rowiter.err, part)
+	}
 
 	keys := util.Keys(a.makeKeys(cleanHeads(hs)), a.suffix)
-	frame = dt.NewFrame(keys...)
+	frame, err := dt.TryNewFrame(keys...)
+	if err != nil {
+		return nil, err
+	}
 	lists := frame.Lists()
 	for rowiter.next() {
 		row := rowiter.row()
@@ -146,8 +169,17 @@ func (a *Reader) ReadWorkbook(workbook *Workbook) (frame *dt.Frame, err error) {
 			if celliter.next() {
 				v = workbook.value(celliter.cell())
 			}
+			if s, ok := v.(dt.String); ok && a.na[strings.TrimSpace(string(s))] {
+				v = dt.Null{}
+			}
 			lists[i] = append(list, v)
 		}
+		if celliter.err != nil {
+			return nil, withPart(celliter.err, part)
+		}
+	}
+	if rowiter.err != nil {
+		return nil, withPart(rowiter.err, part)
 	}
 
 	n := frame.Len() - a.tail
@@ -157,7 +189,7 @@ func (a *Reader) ReadWorkbook(workbook *Workbook) (frame *dt.Frame, err error) {
 	for i, list := range lists {
 		lists[i] = list[:n]
 	}
-	return
+	return frame, nil
 }
 
 func (a *Reader) makeKeys(hs [][]string) []string {
@@ -1,14 +1,22 @@
 package xlsx
 
+import (
+	"errors"
+)
+
 // RowIter is a row iter.
 type RowIter struct {
 	i    int
 	j    int
 	r    int
 	rows []*Row
+	err  error
 }
 
 func (a *RowIter) next() bool {
+	if a.err != nil {
+		return false
+	}
 	a.i++
 	if a.i <= a.r {
 		return true
@@ -17,11 +25,15 @@ func (a *RowIter) next() bool {
 	if a.j >= len(a.rows) {
 		return false
 	}
-	a.r = RowIndex(a.rows[a.j].Ref)
-	if a.i > a.r {
-		panic("dt/io/xlsx: invalid xlsx file")
+	ref := a.rows[a.j].Ref
+	a.r, a.err = RowIndex(ref)
+	if a.err == nil && a.i > a.r {
+		a.err = &FormatError{
+			Ref: ref,
+			Err: errors.New("row out of order"),
+		}
 	}
-	return true
+	return a.err == nil
 }
 
 func (a *RowIter) row() *Row {
@@ -3,6 +3,7 @@ package xlsx
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 )
 
 // Sheet is a sheet.
@@ -14,18 +15,26 @@ type Sheet struct {
 	sheetdata *Data
 }
 
-func (a *Sheet) data() *Data {
+func (a *Sheet) data() (*Data, error) {
 	if a.sheetdata != nil {
-		return a.sheetdata
+		return a.sheetdata, nil
 	}
-	a.sheetdata = new(Data)
-	if data, ok := a.workbook.files["xl/"+a.target]; ok {
-		if err := xml.NewDecoder(bytes.NewBuffer(data)).Decode(a.sheetdata); err != nil {
-			panic(err)
+	part := "xl/" + a.target
+	data, ok := a.workbook.files[part]
+	if !ok {
+		return nil, &FormatError{
+			Part: part,
+			Err:  errors.New("part not found"),
 		}
-	} else {
-		panic("dt/io/xlsx: invalid xlsx file")
 	}
+	sheetdata := new(Data)
+	if err := xml.NewDecoder(bytes.NewBuffer(data)).Decode(sheetdata); err != nil {
+		return nil, &FormatError{
+			Part: part,
+			Err:  err,
+		}
+	}
+	a.sheetdata = sheetdata
 
 	rows := a.sheetdata.Rows
 	for i := len(rows) - 1; i >= 0; i-- {
@@ -34,7 +43,7 @@ func (a *Sheet) data() *Data {
 			break
 		}
 	}
-	return a.sheetdata
+	return a.sheetdata, nil
 }
 
 func (a *Sheet) update() error {
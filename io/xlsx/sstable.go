@@ -18,7 +18,7 @@ type SItem struct {
 
 func (a SSTable) value(v string) string {
 	if i, err := strconv.Atoi(v); err == nil {
-		if i < len(a.Items) {
+		if i >= 0 && i < len(a.Items) {
 			item := a.Items[i]
 			if item.Text != "" {
 				return item.Text
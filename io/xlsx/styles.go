@@ -0,0 +1,132 @@
+package xlsx
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var regCellXfs = regexp.MustCompile(`<cellXfs\s+count\s*=\s*"\d*"`)
+
+var regNumFmts = regexp.MustCompile(`<numFmts\s+count\s*=\s*"\d*"`)
+
+var regStyleSheet = regexp.MustCompile(`<styleSheet[^>]*>`)
+
+var regFmtLiteral = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+// Styles is the styles.
+type Styles struct {
+	NumFmts []NumFmt `xml:"numFmts>numFmt"`
+	CellXfs []Xf     `xml:"cellXfs>xf"`
+}
+
+// NumFmt is a number format.
+type NumFmt struct {
+	ID   int    `xml:"numFmtId,attr"`
+	Code string `xml:"formatCode,attr"`
+}
+
+// Xf is a cell format.
+type Xf struct {
+	NumFmtID int `xml:"numFmtId,attr"`
+}
+
+func (a Styles) isDate(s string) bool {
+	if s == "" {
+		return false
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 || i >= len(a.CellXfs) {
+		return false
+	}
+	id := a.CellXfs[i].NumFmtID
+	switch {
+	case id >= 14 && id <= 22, id >= 27 && id <= 36, id >= 45 && id <= 47, id >= 50 && id <= 58:
+		return true
+	}
+	for _, f := range a.NumFmts {
+		if f.ID == id {
+			return isDateCode(f.Code)
+		}
+	}
+	return false
+}
+
+func isDateCode(code string) bool {
+	if strings.Contains(code, "[h]") || strings.Contains(code, "[m]") || strings.Contains(code, "[s]") {
+		return true
+	}
+	code = strings.ToLower(regFmtLiteral.ReplaceAllString(code, ""))
+	return strings.ContainsAny(code, "ymdhs")
+}
+
+// style returns the index of a cell format with the number format id,
+// appending one to the styles if necessary.
+func (a *Workbook) style(id int) (string, bool) {
+	for i, xf := range a.styles.CellXfs {
+		if xf.NumFmtID == id {
+			return strconv.Itoa(i), true
+		}
+	}
+	data, ok := a.files["xl/styles.xml"]
+	if !ok {
+		return "", false
+	}
+	k := bytes.Index(data, []byte("</cellXfs>"))
+	if k < 0 {
+		return "", false
+	}
+	n := len(a.styles.CellXfs)
+	xf := `<xf numFmtId="` + strconv.Itoa(id) + `" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>`
+	buf := make([]byte, 0, len(data)+len(xf))
+	buf = append(buf, data[:k]...)
+	buf = append(buf, xf...)
+	buf = append(buf, data[k:]...)
+	buf = regCellXfs.ReplaceAll(buf, []byte(`<cellXfs count="`+strconv.Itoa(n+1)+`"`))
+	a.files["xl/styles.xml"] = buf
+	a.styles.CellXfs = append(a.styles.CellXfs, Xf{NumFmtID: id})
+	return strconv.Itoa(n), true
+}
+
+// numFmt returns the id of the custom number format of the code,
+// appending one to the styles if necessary.
+func (a *Workbook) numFmt(code string) (int, bool) {
+	id := 164
+	for _, f := range a.styles.NumFmts {
+		if f.Code == code {
+			return f.ID, true
+		}
+		if f.ID >= id {
+			id = f.ID + 1
+		}
+	}
+	data, ok := a.files["xl/styles.xml"]
+	if !ok {
+		return 0, false
+	}
+	n := len(a.styles.NumFmts)
+	f := `<numFmt numFmtId="` + strconv.Itoa(id) + `" formatCode="` + code + `"/>`
+	var buf []byte
+	if k := bytes.Index(data, []byte("</numFmts>")); k >= 0 {
+		buf = make([]byte, 0, len(data)+len(f))
+		buf = append(buf, data[:k]...)
+		buf = append(buf, f...)
+		buf = append(buf, data[k:]...)
+		buf = regNumFmts.ReplaceAll(buf, []byte(`<numFmts count="`+strconv.Itoa(n+1)+`"`))
+	} else if loc := regStyleSheet.FindIndex(data); loc != nil {
+		f = `<numFmts count="1">` + f + `</numFmts>`
+		buf = make([]byte, 0, len(data)+len(f))
+		buf = append(buf, data[:loc[1]]...)
+		buf = append(buf, f...)
+		buf = append(buf, data[loc[1]:]...)
+	} else {
+		return 0, false
+	}
+	a.files["xl/styles.xml"] = buf
+	a.styles.NumFmts = append(a.styles.NumFmts, NumFmt{
+		ID:   id,
+		Code: code,
+	})
+	return id, true
+}
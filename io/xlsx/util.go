@@ -2,8 +2,10 @@ package xlsx
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/rand"
 	"path/filepath"
 	"regexp"
@@ -19,60 +21,76 @@ var rnd = rand.New(rand.NewSource(time.Now().Unix()))
 
 var regCalcID = regexp.MustCompile(`<\s*calcPr\s+calcId\s*=\s*"\d*"`)
 
-// RowRef returns the row ref by index.
-func RowRef(i int) string {
+// RowRef returns the row ref by index, or returns an error if the index is negative.
+func RowRef(i int) (string, error) {
 	if i < 0 {
-		panic(fmt.Errorf("dt/io/xlsx: invalid row index: %v", i))
+		return "", fmt.Errorf("dt/io/xlsx: invalid row index: %v", i)
 	}
+	return rowRef(i), nil
+}
+
+func rowRef(i int) string {
 	return strconv.Itoa(i + 1)
 }
 
-// RowIndex returns the row index by ref.
-func RowIndex(r string) int {
+// RowIndex returns the row index by ref, or returns an error if the ref is invalid.
+func RowIndex(r string) (int, error) {
 	i, err := strconv.Atoi(r)
-	if err != nil {
-		panic(err)
-	}
-	if i < 1 {
-		panic(fmt.Errorf("dt/io/xlsx: invalid row ref: %v", r))
+	if err != nil || i < 1 {
+		return 0, &FormatError{
+			Ref: r,
+			Err: errors.New("invalid row ref"),
+		}
 	}
-	return i - 1
+	return i - 1, nil
 }
 
-// ColRef returns the col ref by index.
-func ColRef(i int) string {
+// ColRef returns the col ref by index, or returns an error if the index is negative.
+func ColRef(i int) (string, error) {
 	if i < 0 {
-		panic(fmt.Errorf("dt/io/xlsx: invalid col index: %v", i))
+		return "", fmt.Errorf("dt/io/xlsx: invalid col index: %v", i)
 	}
+	return colRef(i), nil
+}
+
+func colRef(i int) string {
 	if i < 26 {
-		return string('A' + i)
+		return string(rune('A' + i))
 	}
-	return ColRef(i/26-1) + ColRef(i%26)
+	return colRef(i/26-1) + colRef(i%26)
 }
 
-// ColIndex returns the col index by ref.
-func ColIndex(r string) int {
-	n := len(r)
-	if n < 1 {
-		panic("dt/io/xlsx: empty col ref")
+// ColIndex returns the col index by ref, or returns an error if the ref is invalid.
+func ColIndex(r string) (int, error) {
+	if r == "" {
+		return 0, &FormatError{
+			Err: errors.New("empty col ref"),
+		}
 	}
-	if n == 1 {
-		c := r[0]
+	j := 0
+	for k := 0; k < len(r); k++ {
+		c := r[k]
 		if c < 'A' || c > 'Z' {
-			panic(fmt.Errorf("dt/io/xlsx: invalid col ref: %v", r))
+			return 0, &FormatError{
+				Ref: r,
+				Err: errors.New("invalid col ref"),
+			}
 		}
-		return int(c - 'A')
+		j = 26*j + int(c-'A') + 1
 	}
-	return ColIndex(r[n-1:n]) + 26*(ColIndex(r[:n-1])+1)
+	return j - 1, nil
 }
 
-// CellRef returns the cell ref by index.
-func CellRef(i, j int) string {
-	return ColRef(j) + RowRef(i)
+// CellRef returns the cell ref by index, or returns an error if an index is negative.
+func CellRef(i, j int) (string, error) {
+	if i < 0 || j < 0 {
+		return "", fmt.Errorf("dt/io/xlsx: invalid cell index: %v, %v", i, j)
+	}
+	return colRef(j) + rowRef(i), nil
 }
 
-// CellIndex returns the cell index by ref.
-func CellIndex(r string) (int, int) {
+// CellIndex returns the cell index by ref, or returns an error if the ref is invalid.
+func CellIndex(r string) (int, int, error) {
 	var k int
 	n := len(r)
 	for k = 0; k < n; k++ {
@@ -80,17 +98,39 @@ func CellIndex(r string) (int, int) {
 			break
 		}
 	}
-	return RowIndex(r[k:]), ColIndex(r[:k])
+	i, err := RowIndex(r[k:])
+	if err != nil {
+		return 0, 0, &FormatError{
+			Ref: r,
+			Err: errors.New("invalid cell ref"),
+		}
+	}
+	j, err := ColIndex(r[:k])
+	if err != nil {
+		return 0, 0, &FormatError{
+			Ref: r,
+			Err: errors.New("invalid cell ref"),
+		}
+	}
+	return i, j, nil
 }
 
 // Time converts a dt.Value to time.Time.
+// A dt.Time is returned as is, others are taken as Excel serial numbers.
 func Time(v dt.Value) time.Time {
-	return time.Unix(int64((v.Number()-25569)*86400), 0).UTC()
+	if t, ok := v.(dt.Time); ok {
+		return time.Time(t)
+	}
+	ms := int64(math.Round((v.Number() - 25569) * 86400000))
+	return time.Unix(ms/1000, ms%1000*1000000).UTC()
 }
 
 // Value converts a time.Time to dt.Value.
+// The wall clock of v is taken, as Excel serial numbers have no time zone.
 func Value(v time.Time) dt.Value {
-	return dt.Number(v.Unix())/86400 + 25569
+	_, offset := v.Zone()
+	s := float64(v.Unix()+int64(offset)) + float64(v.Nanosecond())/1e9
+	return dt.Number(s/86400 + 25569)
 }
 
 func readZipFile(f *zip.File) ([]byte, error) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +23,7 @@ type Workbook struct {
 	files  map[string]([]byte)
 	rels   Rels
 	sst    SSTable
+	styles Styles
 }
 
 // OpenFile opens the workbook from a file.
@@ -70,26 +72,52 @@ func OpenZip(zr *zip.Reader) (*Workbook, error) {
 	workbook := new(Workbook)
 	if data, ok := files["xl/workbook.xml"]; ok {
 		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(workbook); err != nil {
-			return nil, err
+			return nil, &FormatError{
+				Part: "xl/workbook.xml",
+				Err:  err,
+			}
 		}
 	} else {
-		return nil, errors.New("dt/io/xlsx: invalid xlsx file")
+		return nil, &FormatError{
+			Part: "xl/workbook.xml",
+			Err:  errors.New("part not found"),
+		}
 	}
 	if len(workbook.Sheets) < 1 {
-		return nil, errors.New("dt/io/xlsx: no sheet")
+		return nil, &FormatError{
+			Part: "xl/workbook.xml",
+			Err:  errors.New("no sheet"),
+		}
 	}
 
 	if data, ok := files["xl/_rels/workbook.xml.rels"]; ok {
 		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&workbook.rels); err != nil {
-			return nil, err
+			return nil, &FormatError{
+				Part: "xl/_rels/workbook.xml.rels",
+				Err:  err,
+			}
 		}
 	} else {
-		return nil, errors.New("dt/io/xlsx: invalid xlsx file")
+		return nil, &FormatError{
+			Part: "xl/_rels/workbook.xml.rels",
+			Err:  errors.New("part not found"),
+		}
 	}
 
 	if data, ok := files["xl/sharedStrings.xml"]; ok {
 		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&workbook.sst); err != nil {
-			return nil, err
+			return nil, &FormatError{
+				Part: "xl/sharedStrings.xml",
+				Err:  err,
+			}
+		}
+	}
+	if data, ok := files["xl/styles.xml"]; ok {
+		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&workbook.styles); err != nil {
+			return nil, &FormatError{
+				Part: "xl/styles.xml",
+				Err:  err,
+			}
 		}
 	}
 	workbook.files = files
@@ -129,32 +157,47 @@ func (a *Workbook) WriteZip(zw *zip.Writer) error {
 	return zw.Close()
 }
 
-// Value returns the value by ref.
-func (a *Workbook) Value(sheet string, ref string) (v dt.Value) {
-	defer recover()
+// Value returns the value by ref, or nil if not found.
+func (a *Workbook) Value(sheet string, ref string) dt.Value {
+	v, _ := a.TryValue(sheet, ref)
+	return v
+}
+
+// TryValue returns the value by ref, or returns an error if the sheet or ref is invalid.
+func (a *Workbook) TryValue(sheet string, ref string) (dt.Value, error) {
 	ref = strings.ToUpper(ref)
-	ri, ci := CellIndex(ref)
-	rr := RowRef(ri)
-	data := a.sheet(sheet).data()
+	ri, ci, err := CellIndex(ref)
+	if err != nil {
+		return nil, err
+	}
+	rr := rowRef(ri)
+	s, err := a.sheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.data()
+	if err != nil {
+		return nil, err
+	}
 	for i, row := range data.Rows {
 		if i > ri {
-			return
+			break
 		}
 		if row.Ref == rr {
 			for j, cell := range row.Cells {
 				if j > ci {
-					return
+					break
 				}
 				if cell.Ref == ref {
-					return a.value(cell)
+					return a.value(cell), nil
 				}
 			}
 		}
 	}
-	return
+	return nil, nil
 }
 
-func (a *Workbook) sheet(name string) *Sheet {
+func (a *Workbook) sheet(name string) (*Sheet, error) {
 	if name == "" {
 		return a.sheet(a.Sheets[0].Name)
 	}
@@ -169,10 +212,13 @@ func (a *Workbook) sheet(name string) *Sheet {
 					}
 				}
 			}
-			return sheet
+			return sheet, nil
 		}
 	}
-	panic("dt/io/xlsx: sheet not found: " + name)
+	return nil, &SheetError{
+		Sheet: name,
+		Err:   ErrSheetNotFound,
+	}
 }
 
 func (a *Workbook) value(cell *Cell) dt.Value {
@@ -184,7 +230,31 @@ func (a *Workbook) value(cell *Cell) dt.Value {
 		return dt.String(a.sst.value(cell.Value))
 	case "inlineStr":
 		return dt.String(cell.Value)
+	case "b":
+		return dt.Bool(cell.Value == "1")
+	case "e":
+		switch cell.Value {
+		case "#NUM!", "#DIV/0!":
+			return dt.Number(math.NaN())
+		}
+		return dt.Null{}
+	case "d":
+		if v, ok := util.Time(cell.Value); ok {
+			return dt.Time(v)
+		}
+		return dt.String(cell.Value)
 	default:
-		return util.Value(cell.Value)
+		if cell.Value == "" {
+			// Cells without values, such as the blanks of merged cells, are missing.
+			return nil
+		}
+		v := util.Value(cell.Value)
+		switch v.(type) {
+		case dt.Number, dt.Int, dt.Decimal:
+			if a.styles.isDate(cell.Style) {
+				return dt.Time(Time(v))
+			}
+		}
+		return v
 	}
 }
@@ -1,16 +1,22 @@
 package xlsx
 
 import (
-	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/ofunc/dt"
 )
 
+// dateTimeFormat is the number format of times which are not at midnight.
+const dateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+
 // Writer is the xlsx writer.
 type Writer struct {
 	template string
 	filename string
 	sheet    string
+	null     string
+	nan      string
 }
 
 // NewWriter creates a new writer.
@@ -27,6 +33,21 @@ func (a *Writer) Sheet(o string) *Writer {
 	return a
 }
 
+// Null is the output token of missing values.
+// By default, missing values are written as empty cells, which Reader reads back as nil.
+func (a *Writer) Null(o string) *Writer {
+	a.null = o
+	return a
+}
+
+// NaN is the output token of NaN numbers.
+// By default, NaN numbers are written as #NUM! errors, which Reader reads back as NaN,
+// so missing values and NaN numbers are kept apart through xlsx files.
+func (a *Writer) NaN(o string) *Writer {
+	a.nan = o
+	return a
+}
+
 // Template is the template option.
 func (a *Writer) Template(o string) *Writer {
 	a.template = o
@@ -34,21 +55,20 @@ func (a *Writer) Template(o string) *Writer {
 }
 
 // WriteFile writes frame to a xlsx file.
-func (a *Writer) WriteFile(frame *dt.Frame) (err error) {
-	defer func() {
-		if e := recover(); e != nil {
-			err = fmt.Errorf("%v", e)
-		}
-	}()
+func (a *Writer) WriteFile(frame *dt.Frame) error {
+	workbook, err := OpenFile(a.template)
+	if err != nil {
+		return err
+	}
 
 	var rows []*Row
 	row := &Row{
-		Ref: RowRef(0),
+		Ref: rowRef(0),
 	}
 	j := 0
 	for _, key := range frame.Keys() {
 		cell := &Cell{
-			Ref:   CellRef(0, j),
+			Ref:   colRef(j) + rowRef(0),
 			Type:  "inlineStr",
 			Value: key,
 		}
@@ -60,23 +80,64 @@ func (a *Writer) WriteFile(frame *dt.Frame) (err error) {
 	lists := frame.Lists()
 	n, m := frame.Len(), len(lists)
 	for i := 0; i < n; i++ {
-		ref := RowRef(i + 1)
+		ref := rowRef(i + 1)
 		row := &Row{
 			Ref: ref,
 		}
 		for j := 0; j < m; j++ {
 			cell := &Cell{
-				Ref: ColRef(j) + ref,
+				Ref: colRef(j) + ref,
 			}
 			value := lists[j][i]
-			if value != nil {
+			if !dt.IsNull(value) {
 				cell.Value = value.String()
 			}
-			switch value.(type) {
-			case nil:
+			switch v := value.(type) {
+			case nil, dt.Null:
 				cell.Type = "e"
+				if a.null != "" {
+					cell.Type = "inlineStr"
+					cell.Value = a.null
+				}
 			case dt.Number:
 				cell.Type = "n"
+				if dt.IsNaN(v) {
+					cell.Type = "e"
+					cell.Value = "#NUM!"
+					if a.nan != "" {
+						cell.Type = "inlineStr"
+						cell.Value = a.nan
+					}
+				}
+			case dt.Decimal:
+				// Excel keeps 15 significant digits only.
+				if c := v.Coef(); c < -1e15 || c > 1e15 {
+					cell.Type = "inlineStr"
+				} else {
+					cell.Type = "n"
+				}
+			case dt.Int:
+				// Excel keeps 15 significant digits only.
+				if v < -1e15 || v > 1e15 {
+					cell.Type = "inlineStr"
+				} else {
+					cell.Type = "n"
+				}
+			case dt.Bool:
+				cell.Type = "b"
+				cell.Value = strconv.Itoa(int(v.Number()))
+			case dt.Time:
+				cell.Type = "n"
+				cell.Value = Value(time.Time(v)).String()
+				id, ok := 14, true
+				if t := time.Time(v); t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+					id, ok = workbook.numFmt(dateTimeFormat)
+				}
+				if ok {
+					if style, ok := workbook.style(id); ok {
+						cell.Style = style
+					}
+				}
 			default:
 				cell.Type = "inlineStr"
 			}
@@ -85,12 +146,15 @@ func (a *Writer) WriteFile(frame *dt.Frame) (err error) {
 		rows = append(rows, row)
 	}
 
-	workbook, err := OpenFile(a.template)
+	sheet, err := workbook.sheet(a.sheet)
+	if err != nil {
+		return err
+	}
+	data, err := sheet.data()
 	if err != nil {
 		return err
 	}
-	sheet := workbook.sheet(a.sheet)
-	sheet.data().Rows = rows
+	data.Rows = rows
 	if err := sheet.update(); err != nil {
 		return err
 	}
@@ -0,0 +1,261 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+	"github.com/ofunc/dt/io/xlsx"
+)
+
+// parts are the parts of a minimal workbook with an empty sheet.
+var parts = map[string]string{
+	"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`,
+	"_rels/.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`,
+	"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets><calcPr calcId="1"/></workbook>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`,
+	"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="1"><font/></fonts><fills count="1"><fill/></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf numFmtId="0"/></cellStyleXfs><cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs></styleSheet>`,
+}
+
+// workbook creates a workbook file in a temporary directory, whose sheet has the data.
+func workbook(t *testing.T, sheetData string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "dt-xlsx-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	name := filepath.Join(dir, "book.xlsx")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for part, data := range parts {
+		if part == "xl/worksheets/sheet1.xml" && sheetData != "" {
+			data = strings.Replace(data, "<sheetData/>", "<sheetData>"+sheetData+"</sheetData>", 1)
+		}
+		w, err := zw.Create(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+// part returns the data of the part of the workbook file.
+func part(t *testing.T, name, part string) string {
+	t.Helper()
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.Name == part {
+			r, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			data, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(data)
+		}
+	}
+	t.Fatalf("part %s is not found", part)
+	return ""
+}
+
+func TestWriteBoolTime(t *testing.T) {
+	name := workbook(t, "")
+	want := dt.NewFrame().
+		Add("ok", dt.List{dt.Bool(true), dt.Bool(false), nil}).
+		Add("at", dt.List{
+			dt.Time(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+			dt.Time(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+			dt.Time(time.Date(2020, 1, 2, 0, 0, 0, 250000000, time.UTC)),
+		})
+	if err := xlsx.NewWriter(name).WriteFile(want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := xlsx.NewReader().ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dttest.AssertFrameEqual(t, want, got)
+
+	styles := part(t, name, "xl/styles.xml")
+	if !strings.Contains(styles, `formatCode="yyyy-mm-dd hh:mm:ss"`) {
+		t.Errorf("the date time format is not written:\n%s", styles)
+	}
+	if n := strings.Count(styles, "<numFmt "); n != 1 {
+		t.Errorf("got %d number formats, want 1", n)
+	}
+}
+
+func TestWriteExact(t *testing.T) {
+	name := workbook(t, "")
+	frame := dt.NewFrame().
+		Add("int", dt.List{dt.Int(123), dt.Int(1234567890123456789)}).
+		Add("decimal", dt.List{dt.NewDecimal(150, 2), dt.NewDecimal(1234567890123456789, 2)})
+	if err := xlsx.NewWriter(name).WriteFile(frame); err != nil {
+		t.Fatal(err)
+	}
+	got, err := xlsx.NewReader().ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// values of more than 15 significant digits are written as strings.
+	want := dt.NewFrame().
+		Add("int", dt.List{dt.Int(123), dt.String("1234567890123456789")}).
+		Add("decimal", dt.List{dt.NewDecimal(150, 2), dt.String("12345678901234567.89")})
+	dttest.AssertFrameEqual(t, want, got)
+}
+
+func TestNA(t *testing.T) {
+	name := workbook(t, "")
+	frame := dt.NewFrame().
+		Add("x", dt.List{nil, dt.Number(math.NaN()), dt.Number(1)})
+	if err := xlsx.NewWriter(name).WriteFile(frame); err != nil {
+		t.Fatal(err)
+	}
+	got, err := xlsx.NewReader().ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x := got.Get("x"); !dt.IsNull(x[0]) || !dt.IsNaN(x[1]) || !dt.Equal(x[2], dt.Int(1)) {
+		t.Errorf("got %#v, want [NA NaN 1]", x)
+	}
+
+	name = workbook(t, `<row r="1"><c r="A1" t="str"><v>x</v></c></row>`+
+		`<row r="2"><c r="A2" t="e"><v>#DIV/0!</v></c></row>`+
+		`<row r="3"><c r="A3" t="e"><v>#N/A</v></c></row>`+
+		`<row r="4"><c r="A4" t="str"><v> - </v></c></row>`+
+		`<row r="5"><c r="A5" t="str"><v>a</v></c></row>`)
+	got, err = xlsx.NewReader().NA("-").ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x := got.Get("x"); !dt.IsNaN(x[0]) || !dt.IsNull(x[1]) || !dt.IsNull(x[2]) || x[3] != dt.String("a") {
+		t.Errorf("got %#v, want [NaN NA NA a]", x)
+	}
+}
+
+func TestRefs(t *testing.T) {
+	if r, err := xlsx.CellRef(1, 27); err != nil || r != "AB2" {
+		t.Errorf("got %q, %v, want AB2", r, err)
+	}
+	if i, j, err := xlsx.CellIndex("AB2"); err != nil || i != 1 || j != 27 {
+		t.Errorf("got %v, %v, %v, want 1, 27", i, j, err)
+	}
+	if _, err := xlsx.RowRef(-1); err == nil {
+		t.Error("a ref of a negative row index is returned")
+	}
+	if _, err := xlsx.ColRef(-1); err == nil {
+		t.Error("a ref of a negative col index is returned")
+	}
+	for _, r := range []string{"", "A", "1", "A0", "a1", "A1B"} {
+		if _, _, err := xlsx.CellIndex(r); !errors.Is(err, xlsx.ErrInvalidXLSX) {
+			t.Errorf("got %v of ref %q, want ErrInvalidXLSX", err, r)
+		}
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	name := workbook(t, `<row r="1"><c r="A1" t="str"><v>x</v></c></row>`+
+		`<row r="2"><c r="A2" t="s"><v>-1</v></c></row>`)
+	got, err := xlsx.NewReader().ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x := got.Get("x"); x[0] != dt.String("-1") {
+		t.Errorf("got %#v, want [-1]", x)
+	}
+
+	name = workbook(t, `<row r="x"><c r="A1" t="str"><v>x</v></c></row>`)
+	if _, err := xlsx.NewReader().ReadFile(name); !errors.Is(err, xlsx.ErrInvalidXLSX) {
+		t.Errorf("got %v of an invalid row ref, want ErrInvalidXLSX", err)
+	}
+	name = workbook(t, `<row r="1"><c r="1A" t="str"><v>x</v></c></row>`)
+	if _, err := xlsx.NewReader().ReadFile(name); !errors.Is(err, xlsx.ErrInvalidXLSX) {
+		t.Errorf("got %v of an invalid cell ref, want ErrInvalidXLSX", err)
+	}
+}
+
+func TestSheet(t *testing.T) {
+	name := workbook(t, `<row r="1"><c r="A1" t="str"><v>x</v></c></row>`+
+		`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="str"><v>a</v></c></row>`)
+	if _, err := xlsx.NewReader().Sheet("x").ReadFile(name); !errors.Is(err, xlsx.ErrSheetNotFound) {
+		t.Errorf("got %v, want ErrSheetNotFound", err)
+	}
+	if err := xlsx.NewWriter(name).Sheet("x").WriteFile(dt.NewFrame("x")); !errors.Is(err, xlsx.ErrSheetNotFound) {
+		t.Errorf("got %v, want ErrSheetNotFound", err)
+	}
+
+	book, err := xlsx.OpenFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := book.TryValue("Sheet1", "b2"); err != nil || v != dt.String("a") {
+		t.Errorf("got %#v, %v, want a", v, err)
+	}
+	if v := book.Value("", "A2"); !dt.Equal(v, dt.Int(1)) {
+		t.Errorf("got %#v, want 1", v)
+	}
+	if v, err := book.TryValue("", "C9"); err != nil || v != nil {
+		t.Errorf("got %#v, %v, want nil", v, err)
+	}
+	if _, err := book.TryValue("x", "A1"); !errors.Is(err, xlsx.ErrSheetNotFound) {
+		t.Errorf("got %v, want ErrSheetNotFound", err)
+	}
+	if _, err := book.TryValue("", "1A"); !errors.Is(err, xlsx.ErrInvalidXLSX) {
+		t.Errorf("got %v, want ErrInvalidXLSX", err)
+	}
+}
+
+func TestMergedBlanks(t *testing.T) {
+	name := workbook(t, `<row r="1"><c r="A1" t="str"><v>g</v></c><c r="B1" t="str"><v>x</v></c></row>`+
+		`<row r="2"><c r="A2" t="str"><v>a</v></c><c r="B2"><v>1</v></c></row>`+
+		`<row r="3"><c r="A3"/><c r="B3"><v>2</v></c></row>`+
+		`<row r="4"><c r="A4" t="str"><v>b</v></c><c r="B4"><v>3</v></c></row>`)
+	got, err := xlsx.NewReader().ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := got.Get("g")[1]; v != nil {
+		t.Errorf("got %#v, want nil", v)
+	}
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		g | x
+		a | 1
+		a | 2
+		b | 3
+	`), got.FFill(0, "g"))
+}
@@ -1,11 +1,16 @@
 package dt
 
+import (
+	"fmt"
+)
+
 // Join is the join option.
 type Join struct {
 	lframe *Frame
 	rframe *Frame
 	lkeys  []string
 	rkeys  []string
+	norm   normalizer
 }
 
 // On sets the left keys.
@@ -15,12 +20,28 @@ func (a *Join) On(key string, keys ...string) *Join {
 }
 
 // Do does the join operation.
+// It panics if the keys are invalid.
 func (a *Join) Do(prefix string) *Frame {
+	frame, err := a.TryDo(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the join operation, or returns an error if the keys are invalid.
+func (a *Join) TryDo(prefix string) (*Frame, error) {
 	if len(a.lkeys) == 0 {
 		a.lkeys = a.rkeys
 	}
-	if len(a.lkeys) != len(a.rkeys) {
-		panic("dt.Join: number of the left keys not equals to the right keys")
+	if n, m := len(a.rkeys), len(a.lkeys); n != m {
+		return nil, fmt.Errorf("%w, expected %v, got %v", ErrKeyCount, n, m)
+	}
+	if err := a.lframe.Check(a.lkeys...); err != nil {
+		return nil, err
+	}
+	if err := a.rframe.Check(a.rkeys...); err != nil {
+		return nil, err
 	}
 
 	m := len(a.lframe.lists)
@@ -32,39 +53,98 @@ func (a *Join) Do(prefix string) *Frame {
 	for key, j := range rframe.index {
 		keys[j+m] = prefix + key
 	}
-	frame := NewFrame(keys...)
-	copy(frame.lists, a.lframe.lists)
+	frame, err := TryNewFrame(keys...)
+	if err != nil {
+		return nil, err
+	}
+	frame.workers = a.lframe.workers
+	for j, l := range a.lframe.lists {
+		a.lframe.shared[j] = true
+		frame.lists[j] = l
+		frame.shared[j] = true
+	}
 
 	n := a.lframe.Len()
 	for j := range rframe.lists {
 		frame.lists[j+m] = make(List, n)
 	}
 
-	idx := a.index()
-	lists := make([]List, len(a.lkeys))
-	for j, key := range a.lkeys {
-		lists[j] = a.lframe.Get(key)
-	}
+	lists, _ := a.lframe.gets(a.lkeys)
+	lists = a.norm.lists(lists)
+	find := a.matcher(lists)
 	for i, n := 0, a.lframe.Len(); i < n; i++ {
-		if k, ok := idx[makeKey(i, lists)]; ok {
+		if a.norm.na != NAMatch && a.norm.isNA(i, lists) {
+			continue
+		}
+		if k := find(i); k >= 0 {
 			for j, l := range rframe.lists {
 				frame.lists[m+j][i] = l[k]
 			}
 		}
 	}
-	return frame
+	return frame, nil
 }
 
-func (a *Join) index() map[string]int {
+// matcher returns the function to find the last right row matching row i of the left key lists, or -1.
+func (a *Join) matcher(llists []List) func(i int) int {
 	frame := a.rframe
 	n := frame.Len()
-	lists := make([]List, len(a.rkeys))
-	for j, key := range a.rkeys {
-		lists[j] = frame.Get(key)
+	lists, _ := frame.gets(a.rkeys)
+	lists = a.norm.lists(lists)
+	if len(lists) == 1 {
+		if ldict, ok := categories(llists[0]); ok {
+			if rdict, ok := categories(lists[0]); ok {
+				return a.matchCodes(llists[0], ldict, lists[0], rdict)
+			}
+		}
 	}
-	idx := make(map[string]int, n)
+	idx := newTable(lists)
 	for i := 0; i < n; i++ {
-		idx[makeKey(i, lists)] = i
+		if a.norm.na != NAMatch && a.norm.isNA(i, lists) {
+			continue
+		}
+		if g, ok := idx.insert(i); !ok {
+			idx.rows[g] = i
+		}
+	}
+	return func(i int) int {
+		if g := idx.find(i, llists); g >= 0 {
+			return idx.rows[g]
+		}
+		return -1
+	}
+}
+
+// matchCodes is matcher of lists of categories, which matches rows by the codes.
+func (a *Join) matchCodes(llist List, ldict *Categories, rlist List, rdict *Categories) func(i int) int {
+	rows := make([]int, rdict.Len()+1)
+	for k := range rows {
+		rows[k] = -1
+	}
+	for i, v := range rlist {
+		if c, ok := v.(*Category); ok {
+			rows[c.code+1] = i
+		} else if a.norm.na == NAMatch {
+			rows[0] = i
+		}
+	}
+	// codes maps the left codes to the right ones.
+	codes := make([]int, ldict.Len())
+	for k, c := range ldict.levels {
+		codes[k] = -1
+		if ldict == rdict {
+			codes[k] = k
+		} else if d := rdict.Get(c.value); d != nil {
+			codes[k] = d.code
+		}
+	}
+	return func(i int) int {
+		if c, ok := llist[i].(*Category); ok {
+			if k := codes[c.code]; k >= 0 {
+				return rows[k+1]
+			}
+			return -1
+		}
+		return rows[0]
 	}
-	return idx
 }
@@ -1,22 +1,21 @@
 package dt
 
 // List is the list data structure.
+// The methods of list never mutate the receiver, and return new lists.
 type List []Value
 
 // Number converts the list to float list.
 func (a List) Number() List {
-	for i, v := range a {
-		a[i] = Number(v.Number())
-	}
-	return a
+	return a.Map(func(v Value) Value {
+		return Number(v.Number())
+	})
 }
 
 // String converts the list to string list.
 func (a List) String() List {
-	for i, v := range a {
-		a[i] = String(v.String())
-	}
-	return a
+	return a.Map(func(v Value) Value {
+		return String(v.String())
+	})
 }
 
 // Map maps the list by function f.
@@ -41,6 +40,10 @@ func (a List) Filter(f func(Value) bool) List {
 
 // FillNA fills NA value with value.
 func (a List) FillNA(value Value) List {
+	return a.clone().fillNA(value)
+}
+
+func (a List) fillNA(value Value) List {
 	for i, v := range a {
 		if IsNA(v) {
 			a[i] = value
@@ -48,3 +51,12 @@ func (a List) FillNA(value Value) List {
 	}
 	return a
 }
+
+func (a List) clone() List {
+	if a == nil {
+		return nil
+	}
+	b := make(List, len(a))
+	copy(b, a)
+	return b
+}
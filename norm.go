@@ -0,0 +1,152 @@
+package dt
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/width"
+)
+
+// NAKeys is the option of NA keys in Join and GroupBy.
+type NAKeys int
+
+// The options of NA keys.
+const (
+	// NAMatch matches NA keys to each other, and groups them together.
+	NAMatch NAKeys = iota
+	// NADrop never matches NA keys, and drops them from groups.
+	NADrop
+	// NASeparate never matches NA keys, and makes each of them a group of its own.
+	NASeparate
+)
+
+// normalizer is the key normalization options.
+type normalizer struct {
+	trim    bool
+	fold    bool
+	width   bool
+	numeric bool
+	na      NAKeys
+}
+
+func (a normalizer) enabled() bool {
+	return a.trim || a.fold || a.width || a.numeric
+}
+
+// lists returns the normalized key lists.
+func (a normalizer) lists(lists []List) []List {
+	if !a.enabled() {
+		return lists
+	}
+	fold := cases.Fold()
+	ls := make([]List, len(lists))
+	for j, list := range lists {
+		ls[j] = list.Map(func(v Value) Value {
+			if IsNA(v) || rank(v) != rankString {
+				return v
+			}
+			s := v.String()
+			if a.trim {
+				s = strings.TrimSpace(s)
+			}
+			if a.width {
+				s = width.Fold.String(s)
+			}
+			if a.fold {
+				s = fold.String(s)
+			}
+			if a.numeric {
+				if v, ok := parseNumber(s); ok {
+					return v
+				}
+			}
+			return String(s)
+		})
+	}
+	return ls
+}
+
+// isNA checks if the keys of row i have NA.
+func (a normalizer) isNA(i int, lists []List) bool {
+	for _, list := range lists {
+		if IsNA(list[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNumber(s string) (Value, bool) {
+	x := strings.TrimSpace(s)
+	if v, err := strconv.ParseInt(x, 10, 64); err == nil {
+		return Int(v), true
+	}
+	if v, err := ParseDecimal(x); err == nil {
+		return v, true
+	}
+	if v, err := strconv.ParseFloat(x, 64); err == nil {
+		return Number(v), true
+	}
+	return nil, false
+}
+
+// Trim is the option to trim white spaces of string keys.
+func (a *Join) Trim(o bool) *Join {
+	a.norm.trim = o
+	return a
+}
+
+// Fold is the option to match string keys case-insensitively.
+func (a *Join) Fold(o bool) *Join {
+	a.norm.fold = o
+	return a
+}
+
+// Width is the option to match full-width and half-width string keys.
+func (a *Join) Width(o bool) *Join {
+	a.norm.width = o
+	return a
+}
+
+// Numeric is the option to take numeric string keys as numbers, so "001" matches 1.
+func (a *Join) Numeric(o bool) *Join {
+	a.norm.numeric = o
+	return a
+}
+
+// NA is the option of NA keys, NAMatch by default.
+func (a *Join) NA(o NAKeys) *Join {
+	a.norm.na = o
+	return a
+}
+
+// Trim is the option to trim white spaces of string keys.
+func (a *Group) Trim(o bool) *Group {
+	a.norm.trim = o
+	return a
+}
+
+// Fold is the option to group string keys case-insensitively.
+func (a *Group) Fold(o bool) *Group {
+	a.norm.fold = o
+	return a
+}
+
+// Width is the option to group full-width and half-width string keys together.
+func (a *Group) Width(o bool) *Group {
+	a.norm.width = o
+	return a
+}
+
+// Numeric is the option to take numeric string keys as numbers, so "001" groups with 1.
+func (a *Group) Numeric(o bool) *Group {
+	a.norm.numeric = o
+	return a
+}
+
+// NA is the option of NA keys, NAMatch by default.
+func (a *Group) NA(o NAKeys) *Group {
+	a.norm.na = o
+	return a
+}
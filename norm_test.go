@@ -0,0 +1,36 @@
+package dt_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/dt"
+)
+
+func TestGroupNorm(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("k", dt.List{dt.String(" Apple"), dt.String("apple "), dt.String("ＡＰＰＬＥ"), dt.String("001"), dt.Int(1), nil, dt.Null{}}).
+		Add("x", dt.List{dt.Int(1), dt.Int(2), dt.Int(4), dt.Int(8), dt.Int(16), dt.Int(32), dt.Int(64)})
+	sums := func(g *dt.Group) dt.List {
+		return g.Apply("x", "x", dt.Sum).Do().Get("x")
+	}
+	assertList(t, dt.List{dt.Int(1), dt.Int(2), dt.Int(4), dt.Int(8), dt.Int(16), dt.Int(96)}, sums(frame.GroupBy("k")))
+	assertList(t, dt.List{dt.Int(3), dt.Int(4), dt.Int(8), dt.Int(16), dt.Int(96)}, sums(frame.GroupBy("k").Trim(true).Fold(true)))
+	assertList(t, dt.List{dt.Int(7), dt.Int(24), dt.Int(96)},
+		sums(frame.GroupBy("k").Trim(true).Fold(true).Width(true).Numeric(true)))
+	assertList(t, dt.List{dt.Int(1), dt.Int(2), dt.Int(4), dt.Int(8), dt.Int(16)}, sums(frame.GroupBy("k").NA(dt.NADrop)))
+	assertList(t, dt.List{dt.Int(1), dt.Int(2), dt.Int(4), dt.Int(8), dt.Int(16), dt.Int(32), dt.Int(64)},
+		sums(frame.GroupBy("k").NA(dt.NASeparate)))
+}
+
+func TestJoinNorm(t *testing.T) {
+	left := dt.NewFrame().
+		Add("k", dt.List{dt.String("A"), dt.String("b "), dt.String("01"), nil}).
+		Add("x", dt.List{dt.Int(1), dt.Int(2), dt.Int(3), dt.Int(4)})
+	right := dt.NewFrame().
+		Add("k", dt.List{dt.String("a"), dt.String("B"), dt.Int(1), nil}).
+		Add("y", dt.List{dt.String("a"), dt.String("b"), dt.String("1"), dt.String("na")})
+	got := left.Join(right, "k").Do("r_").Get("r_y")
+	assertList(t, dt.List{nil, nil, nil, dt.String("na")}, got)
+	got = left.Join(right, "k").Trim(true).Fold(true).Numeric(true).NA(dt.NADrop).Do("r_").Get("r_y")
+	assertList(t, dt.List{dt.String("a"), dt.String("b"), dt.String("1"), nil}, got)
+}
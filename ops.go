@@ -0,0 +1,418 @@
+package dt
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scalar returns a list of the value, which broadcasts in the list operations.
+func Scalar(v Value) List {
+	return List{v}
+}
+
+// Add returns a + b element-wise.
+// Int and Decimal values are added exactly unless it overflows.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Add(b List) List {
+	return must(a.TryAdd(b))
+}
+
+// TryAdd returns a + b element-wise, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryAdd(b List) (List, error) {
+	return a.binary(b, func(x, y Value) Value {
+		if x, ok := x.(Int); ok {
+			if y, ok := y.(Int); ok {
+				if z := x + y; (z > x) == (y > 0) {
+					return z
+				}
+			}
+		}
+		if x, ok := toDecimal(x); ok {
+			if y, ok := toDecimal(y); ok {
+				if z, ok := x.add(y); ok {
+					return z
+				}
+			}
+		}
+		return Number(x.Number() + y.Number())
+	})
+}
+
+// Sub returns a - b element-wise.
+// Int and Decimal values are subtracted exactly unless it overflows.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Sub(b List) List {
+	return must(a.TrySub(b))
+}
+
+// TrySub returns a - b element-wise, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TrySub(b List) (List, error) {
+	return a.TryAdd(b.Neg())
+}
+
+// Mul returns a * b element-wise.
+// Int and Decimal values are multiplied exactly unless it overflows.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Mul(b List) List {
+	return must(a.TryMul(b))
+}
+
+// TryMul returns a * b element-wise, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryMul(b List) (List, error) {
+	return a.binary(b, func(x, y Value) Value {
+		if x, ok := x.(Int); ok {
+			if y, ok := y.(Int); ok {
+				if z, ok := mul64(int64(x), int64(y)); ok {
+					return Int(z)
+				}
+			}
+		}
+		if x, ok := toDecimal(x); ok {
+			if y, ok := toDecimal(y); ok {
+				if z, ok := x.mul(y); ok {
+					return z
+				}
+			}
+		}
+		return Number(x.Number() * y.Number())
+	})
+}
+
+// Div returns a / b element-wise as numbers.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Div(b List) List {
+	return must(a.TryDiv(b))
+}
+
+// TryDiv returns a / b element-wise as numbers, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryDiv(b List) (List, error) {
+	return a.binary(b, func(x, y Value) Value {
+		return Number(x.Number() / y.Number())
+	})
+}
+
+// Pow returns a ** b element-wise as numbers.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Pow(b List) List {
+	return must(a.TryPow(b))
+}
+
+// TryPow returns a ** b element-wise as numbers, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryPow(b List) (List, error) {
+	return a.binary(b, func(x, y Value) Value {
+		return Number(math.Pow(x.Number(), y.Number()))
+	})
+}
+
+// Neg returns -a element-wise.
+func (a List) Neg() List {
+	return a.unary(func(x Value) Value {
+		switch v := x.(type) {
+		case Int:
+			if v != math.MinInt64 {
+				return -v
+			}
+		case Decimal:
+			if v.coef != math.MinInt64 {
+				return v.Neg()
+			}
+		}
+		return Number(-x.Number())
+	})
+}
+
+// Abs returns |a| element-wise.
+func (a List) Abs() List {
+	return a.unary(func(x Value) Value {
+		switch v := x.(type) {
+		case Int:
+			if v >= 0 {
+				return v
+			}
+		case Decimal:
+			if v.Sign() >= 0 {
+				return v
+			}
+		}
+		if x.Number() >= 0 {
+			return Number(x.Number())
+		}
+		return List{x}.Neg()[0]
+	})
+}
+
+// Round rounds a half away from zero to the decimal places element-wise.
+// Int and Decimal values with no more decimal places are kept as is.
+func (a List) Round(places int) List {
+	return a.unary(func(x Value) Value {
+		switch v := x.(type) {
+		case Int:
+			if places >= 0 {
+				return v
+			}
+		case Decimal:
+			if places >= v.Scale() {
+				return v
+			}
+			if places >= 0 {
+				return v.Round(places)
+			}
+		}
+		p := math.Pow(10, float64(places))
+		return Number(math.Round(x.Number()*p) / p)
+	})
+}
+
+// Eq returns a == b element-wise as a mask.
+// The comparisons follow the ordering of Compare, except that NaN is unequal to all values,
+// and values of different kinds, such as numbers and strings, are incomparable and give NA.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Eq(b List) List {
+	return must(a.TryEq(b))
+}
+
+// TryEq returns a == b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryEq(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c == 0 }, false)
+}
+
+// Ne returns a != b element-wise as a mask.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Ne(b List) List {
+	return must(a.TryNe(b))
+}
+
+// TryNe returns a != b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryNe(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c != 0 }, true)
+}
+
+// Lt returns a < b element-wise as a mask.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Lt(b List) List {
+	return must(a.TryLt(b))
+}
+
+// TryLt returns a < b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryLt(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c < 0 }, false)
+}
+
+// Le returns a <= b element-wise as a mask.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Le(b List) List {
+	return must(a.TryLe(b))
+}
+
+// TryLe returns a <= b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryLe(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c <= 0 }, false)
+}
+
+// Gt returns a > b element-wise as a mask.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Gt(b List) List {
+	return must(a.TryGt(b))
+}
+
+// TryGt returns a > b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryGt(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c > 0 }, false)
+}
+
+// Ge returns a >= b element-wise as a mask.
+// It panics if the lengths of a and b mismatch and neither is 1.
+func (a List) Ge(b List) List {
+	return must(a.TryGe(b))
+}
+
+// TryGe returns a >= b element-wise as a mask, or returns an error if the lengths of a and b mismatch and neither is 1.
+func (a List) TryGe(b List) (List, error) {
+	return a.compare(b, func(c int) bool { return c >= 0 }, false)
+}
+
+// And returns a && b element-wise, where NA && false is false and NA && true is NA.
+// It panics if the lengths of a and b mismatch and neither is 1, or any value is not a bool or NA.
+func (a List) And(b List) List {
+	return must(a.TryAnd(b))
+}
+
+// TryAnd returns a && b element-wise, where NA && false is false and NA && true is NA,
+// or returns an error if the lengths of a and b mismatch and neither is 1, or any value is not a bool or NA.
+func (a List) TryAnd(b List) (List, error) {
+	if err := checkBool(a, b); err != nil {
+		return nil, err
+	}
+	return broadcast(a, b, func(x, y Value) Value {
+		p, ok1 := truth(x)
+		q, ok2 := truth(y)
+		switch {
+		case (ok1 && !p) || (ok2 && !q):
+			return Bool(false)
+		case ok1 && ok2:
+			return Bool(true)
+		default:
+			return nil
+		}
+	})
+}
+
+// Or returns a || b element-wise, where NA || true is true and NA || false is NA.
+// It panics if the lengths of a and b mismatch and neither is 1, or any value is not a bool or NA.
+func (a List) Or(b List) List {
+	return must(a.TryOr(b))
+}
+
+// TryOr returns a || b element-wise, where NA || true is true and NA || false is NA,
+// or returns an error if the lengths of a and b mismatch and neither is 1, or any value is not a bool or NA.
+func (a List) TryOr(b List) (List, error) {
+	if err := checkBool(a, b); err != nil {
+		return nil, err
+	}
+	return broadcast(a, b, func(x, y Value) Value {
+		p, ok1 := truth(x)
+		q, ok2 := truth(y)
+		switch {
+		case (ok1 && p) || (ok2 && q):
+			return Bool(true)
+		case ok1 && ok2:
+			return Bool(false)
+		default:
+			return nil
+		}
+	})
+}
+
+// Not returns !a element-wise, where !NA is NA.
+// It panics if any value is not a bool or NA.
+func (a List) Not() List {
+	return must(a.TryNot())
+}
+
+// TryNot returns !a element-wise, where !NA is NA, or returns an error if any value is not a bool or NA.
+func (a List) TryNot() (List, error) {
+	if err := checkBool(a); err != nil {
+		return nil, err
+	}
+	return a.Map(func(x Value) Value {
+		if p, ok := truth(x); ok {
+			return Bool(!p)
+		}
+		return nil
+	}), nil
+}
+
+// Mask selects the rows where the mask is true, and returns a new frame.
+// NA is taken as false.
+// It panics if the length of mask is invalid, or any value is not a bool or NA.
+func (a *Frame) Mask(mask List) *Frame {
+	b, err := a.TryMask(mask)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryMask selects the rows where the mask is true,
+// or returns an error if the length of mask is invalid, or any value is not a bool or NA.
+func (a *Frame) TryMask(mask List) (*Frame, error) {
+	if n, m := a.Len(), len(mask); n != m {
+		return nil, &LengthError{
+			Expected: n,
+			Got:      m,
+		}
+	}
+	if err := checkBool(mask); err != nil {
+		return nil, err
+	}
+	is := make([]int, 0, len(mask))
+	for i, v := range mask {
+		if p, ok := truth(v); ok && p {
+			is = append(is, i)
+		}
+	}
+	return a.take(is), nil
+}
+
+func (a List) unary(f func(Value) Value) List {
+	return a.Map(func(x Value) Value {
+		if IsNull(x) {
+			return nil
+		}
+		return f(x)
+	})
+}
+
+func (a List) binary(b List, f func(Value, Value) Value) (List, error) {
+	return broadcast(a, b, func(x, y Value) Value {
+		if IsNull(x) || IsNull(y) {
+			return nil
+		}
+		return f(x, y)
+	})
+}
+
+// compare compares a and b element-wise by Compare, gives nan if either is NaN,
+// and gives nil if they are of different kinds.
+func (a List) compare(b List, f func(int) bool, nan bool) (List, error) {
+	return a.binary(b, func(x, y Value) Value {
+		if IsNaN(x) || IsNaN(y) {
+			return Bool(nan)
+		}
+		if rank(x) != rank(y) {
+			return nil
+		}
+		return Bool(f(Compare(x, y)))
+	})
+}
+
+func broadcast(a, b List, f func(Value, Value) Value) (List, error) {
+	n, m := len(a), len(b)
+	switch {
+	case n == m:
+	case n == 1:
+		n = m
+	case m != 1:
+		return nil, &LengthError{
+			Expected: n,
+			Got:      m,
+		}
+	}
+	l := make(List, n)
+	for i := range l {
+		x, y := a[0], b[0]
+		if len(a) > 1 {
+			x = a[i]
+		}
+		if len(b) > 1 {
+			y = b[i]
+		}
+		l[i] = f(x, y)
+	}
+	return l, nil
+}
+
+func must(l List, err error) List {
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// truth returns the bool of value v, and whether it is a bool.
+func truth(v Value) (bool, bool) {
+	x, ok := v.(Bool)
+	return bool(x), ok
+}
+
+// checkBool checks that the values of the lists are bools or NA.
+func checkBool(ls ...List) error {
+	for _, l := range ls {
+		for _, v := range l {
+			if _, ok := v.(Bool); !ok && !IsNA(v) {
+				return fmt.Errorf("%w: %v", ErrNotBool, v)
+			}
+		}
+	}
+	return nil
+}
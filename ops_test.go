@@ -0,0 +1,103 @@
+package dt_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func TestArithmetic(t *testing.T) {
+	a := dt.List{dt.Int(1), dt.NewDecimal(150, 2), dt.Number(2), nil, dt.Int(math.MaxInt64)}
+	b := dt.Scalar(dt.Int(2))
+	for i, v := range a.Add(b) {
+		want := dt.List{dt.Int(3), dt.NewDecimal(350, 2), dt.Number(4), nil, dt.Number(math.MaxInt64 + 2.0)}[i]
+		if !same(v, want) {
+			t.Errorf("Add[%d] = %#v, want %#v", i, v, want)
+		}
+	}
+	for i, v := range a.Mul(b) {
+		want := dt.List{dt.Int(2), dt.NewDecimal(300, 2), dt.Number(4), nil, dt.Number(math.MaxInt64 * 2.0)}[i]
+		if !same(v, want) {
+			t.Errorf("Mul[%d] = %#v, want %#v", i, v, want)
+		}
+	}
+	assertList(t, dt.List{dt.Int(-1), dt.NewDecimal(-50, 2), dt.Number(0), nil}, a[:4].Sub(b))
+	assertList(t, dt.List{dt.Number(0.5), dt.Number(0.75), dt.Number(1), nil}, a[:4].Div(b))
+	assertList(t, dt.List{dt.Number(1), dt.Number(2.25), dt.Number(4), nil}, a[:4].Pow(b))
+	assertList(t, dt.List{dt.Int(1), dt.NewDecimal(150, 2), dt.Number(2), nil}, a[:4].Neg().Abs())
+	if _, err := a.TryAdd(a[:2]); !errors.Is(err, dt.ErrLengthMismatch) {
+		t.Errorf("got %v, want ErrLengthMismatch", err)
+	}
+}
+
+func TestListRound(t *testing.T) {
+	d, _ := dt.ParseDecimal("92233720368547758.07")
+	l := dt.List{d, dt.NewDecimal(125, 2), dt.Int(3), dt.Number(1.25), nil}
+	want := dt.List{d, dt.NewDecimal(125, 2), dt.Int(3), dt.Number(1.25), nil}
+	for i, v := range l.Round(4) {
+		if !same(v, want[i]) {
+			t.Errorf("Round(4)[%d] = %#v, want %#v", i, v, want[i])
+		}
+	}
+	want = dt.List{dt.NewDecimal(92233720368547758, 0), dt.NewDecimal(1, 0), dt.Int(3), dt.Number(1), nil}
+	for i, v := range l.Round(0) {
+		if !same(v, want[i]) {
+			t.Errorf("Round(0)[%d] = %#v, want %#v", i, v, want[i])
+		}
+	}
+}
+
+func TestComparison(t *testing.T) {
+	nan := dt.Number(math.NaN())
+	a := dt.List{dt.Int(1), dt.NewDecimal(10, 1), dt.Number(2), nan, nil, dt.String("1"), dt.Bool(true)}
+	b := dt.Scalar(dt.Int(1))
+	T, F := dt.Bool(true), dt.Bool(false)
+	assertList(t, dt.List{T, T, F, F, nil, nil, nil}, a.Eq(b))
+	assertList(t, dt.List{F, F, T, T, nil, nil, nil}, a.Ne(b))
+	assertList(t, dt.List{F, F, F, F, nil, nil, nil}, a.Lt(b))
+	assertList(t, dt.List{T, T, F, F, nil, nil, nil}, a.Le(b))
+	assertList(t, dt.List{F, F, T, F, nil, nil, nil}, a.Gt(b))
+	assertList(t, dt.List{T, T, T, F, nil, nil, nil}, a.Ge(b))
+	assertList(t, dt.List{nil, T, F}, dt.List{dt.Int(1), dt.String("b"), dt.String("a")}.Gt(dt.Scalar(dt.String("a"))))
+}
+
+func TestLogic(t *testing.T) {
+	T, F := dt.Bool(true), dt.Bool(false)
+	a := dt.List{T, T, T, F, F, F, nil, nil, nil}
+	b := dt.List{T, F, nil, T, F, nil, T, F, nil}
+	assertList(t, dt.List{T, F, nil, F, F, F, nil, F, nil}, a.And(b))
+	assertList(t, dt.List{T, T, T, T, F, nil, T, nil, nil}, a.Or(b))
+	assertList(t, dt.List{F, F, F, T, T, T, nil, nil, nil}, a.Not())
+
+	for _, l := range []dt.List{{dt.Int(1)}, {dt.String("true")}, {dt.Number(0)}} {
+		if _, err := l.TryNot(); !errors.Is(err, dt.ErrNotBool) {
+			t.Errorf("got %v of %v, want ErrNotBool", err, l)
+		}
+		if _, err := a.TryAnd(l); !errors.Is(err, dt.ErrNotBool) {
+			t.Errorf("got %v of %v, want ErrNotBool", err, l)
+		}
+		if _, err := a.TryOr(l); !errors.Is(err, dt.ErrNotBool) {
+			t.Errorf("got %v of %v, want ErrNotBool", err, l)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	frame := dttest.Frame(`
+		x
+		1
+		2
+		3
+	`)
+	got := frame.Mask(frame.Get("x").Ge(dt.Scalar(dt.Int(2))).And(dt.List{dt.Bool(true), nil, dt.Bool(true)}))
+	dttest.AssertFrameEqual(t, frame.Slice(2, 3), got)
+	if b, err := frame.TryMask(frame.Get("x")); b != nil || !errors.Is(err, dt.ErrNotBool) {
+		t.Errorf("got %v, want ErrNotBool", err)
+	}
+	if _, err := frame.TryMask(dt.List{dt.Bool(true)}); !errors.Is(err, dt.ErrLengthMismatch) {
+		t.Errorf("got %v, want ErrLengthMismatch", err)
+	}
+}
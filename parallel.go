@@ -0,0 +1,57 @@
+package dt
+
+import (
+	"runtime"
+	"sync"
+)
+
+// grain is the minimum number of rows of a chunk.
+const grain = 4096
+
+// split splits n items into chunks of at least g items for the workers,
+// and returns the bounds of the chunks.
+// If workers < 0, the number of CPUs is used.
+func split(n, g, workers int) []int {
+	k := workers
+	if k < 0 {
+		k = runtime.NumCPU()
+	}
+	if m := n / g; m < k {
+		k = m
+	}
+	if k < 1 {
+		k = 1
+	}
+	bounds := make([]int, k+1)
+	for c := range bounds {
+		bounds[c] = c * n / k
+	}
+	return bounds
+}
+
+// parallel calls f for chunks 0 to k-1 concurrently,
+// and panics with the value of the first panicking chunk.
+func parallel(k int, f func(c int)) {
+	if k == 1 {
+		f(0)
+		return
+	}
+	panics := make([]interface{}, k)
+	var wg sync.WaitGroup
+	wg.Add(k)
+	for c := 0; c < k; c++ {
+		go func(c int) {
+			defer wg.Done()
+			defer func() {
+				panics[c] = recover()
+			}()
+			f(c)
+		}(c)
+	}
+	wg.Wait()
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package dt_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+// mixed returns a frame of n rows with all the value types.
+func mixed(n int) *dt.Frame {
+	keys := make(dt.List, n)
+	values := make(dt.List, n)
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	for i := range keys {
+		keys[i] = dt.Int(i * 7919 % 97)
+		switch i % 9 {
+		case 0:
+			values[i] = nil
+		case 1:
+			values[i] = dt.Null{}
+		case 2:
+			values[i] = dt.Number(float64(i) / 3)
+		case 3:
+			values[i] = dt.Number(math.NaN())
+		case 4:
+			values[i] = dt.String("s" + string(rune('a'+i%26)))
+		case 5:
+			values[i] = dt.Bool(i%2 == 0)
+		case 6:
+			values[i] = dt.Int(-i)
+		case 7:
+			values[i] = dt.NewDecimal(int64(i), 2)
+		case 8:
+			values[i] = dt.Time(time.Date(2020, 1, i, 0, 0, 0, i, loc))
+		}
+	}
+	return dt.NewFrame().Add("key", keys).Add("value", values)
+}
+
+func TestParallel(t *testing.T) {
+	tests := map[string]func(*dt.Frame) *dt.Frame{
+		"Map": func(frame *dt.Frame) *dt.Frame {
+			return frame.Copy(false).MapTo("double", func(r dt.Record) dt.Value {
+				return dt.Int(2 * r.Value("key").(dt.Int))
+			})
+		},
+		"Filter": func(frame *dt.Frame) *dt.Frame {
+			return frame.Filter(func(r dt.Record) bool {
+				return r.Value("key").(dt.Int)%3 == 0
+			})
+		},
+		"GroupBy": func(frame *dt.Frame) *dt.Frame {
+			return frame.GroupBy("key").
+				Apply("value", "count", dt.Count).
+				Apply("value", "first", dt.First).
+				Apply("value", "min", dt.Min).
+				Do()
+		},
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			dttest.AssertFrameEqual(t, f(mixed(50000)), f(mixed(50000).Workers(4)))
+			dttest.AssertFrameEqual(t, f(mixed(50000)), f(mixed(50000).Workers(-1)))
+		})
+	}
+}
+
+func TestParallelPanic(t *testing.T) {
+	defer func() {
+		if recover() != "boom" {
+			t.Error("the panic of a worker is not passed to the caller")
+		}
+	}()
+	mixed(50000).Workers(4).Slice(0, 50000).Filter(func(r dt.Record) bool {
+		if r.Value("key").(dt.Int) == 42 {
+			panic("boom")
+		}
+		return true
+	})
+}
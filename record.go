@@ -2,6 +2,7 @@ package dt
 
 import (
 	"math"
+	"time"
 )
 
 // Record is the record interface.
@@ -27,7 +28,7 @@ func (a record) Value(key string) Value {
 
 // Number returns the float64 value by key.
 func (a record) Number(key string) float64 {
-	if v := a.Value(key); v != nil {
+	if v := a.Value(key); !IsNull(v) {
 		return v.Number()
 	}
 	return math.NaN()
@@ -35,8 +36,17 @@ func (a record) Number(key string) float64 {
 
 // String returns the string value by key.
 func (a record) String(key string) string {
-	if v := a.Value(key); v != nil {
+	if v := a.Value(key); !IsNull(v) {
 		return v.String()
 	}
 	return ""
 }
+
+// RecordTime returns the time value of record r by key.
+// The zero time is returned if the value is not a time.
+func RecordTime(r Record, key string) time.Time {
+	if v, ok := r.Value(key).(Time); ok {
+		return time.Time(v)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,221 @@
+package dt
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Period is the period of time.
+type Period int
+
+// The periods.
+const (
+	Minute Period = iota + 1
+	Hour
+	Day
+	Week
+	Month
+	Quarter
+	Year
+)
+
+// Truncate returns the start of the period containing t in the location,
+// with weeks starting at the week start.
+func (p Period) Truncate(t time.Time, loc *time.Location, start time.Weekday) time.Time {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	y, m, d := t.Date()
+	switch p {
+	case Minute:
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, t.Location())
+	case Hour:
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	case Day:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case Week:
+		k := (int(t.Weekday()) - int(start) + 7) % 7
+		return time.Date(y, m, d-k, 0, 0, 0, 0, t.Location())
+	case Month:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case Quarter:
+		return time.Date(y, (m-1)/3*3+1, 1, 0, 0, 0, 0, t.Location())
+	case Year:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		panic("dt: invalid period: " + strconv.Itoa(int(p)))
+	}
+}
+
+// Add adds n periods to t.
+// The day of month is clamped to the end of month, such as Jan 31 + 1 month = Feb 28.
+func (p Period) Add(t time.Time, n int) time.Time {
+	switch p {
+	case Minute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case Hour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case Day:
+		return t.AddDate(0, 0, n)
+	case Week:
+		return t.AddDate(0, 0, 7*n)
+	case Month:
+		return addMonths(t, n)
+	case Quarter:
+		return addMonths(t, 3*n)
+	case Year:
+		return addMonths(t, 12*n)
+	default:
+		panic("dt: invalid period: " + strconv.Itoa(int(p)))
+	}
+}
+
+// DateRange returns the times from start to end inclusively, by the period.
+func DateRange(start, end time.Time, period Period) List {
+	var l List
+	for k := 0; ; k++ {
+		t := period.Add(start, k)
+		if t.After(end) {
+			return l
+		}
+		l = append(l, Time(t))
+	}
+}
+
+// Resample is the resample option.
+type Resample struct {
+	frame  *Frame
+	key    string
+	period Period
+	loc    *time.Location
+	start  time.Weekday
+	fill   bool
+	keys   []string
+	names  []string
+	funcs  [](func(List) Value)
+}
+
+// Resample buckets records by the period of the time list of the key.
+// Records with NA or non-time keys are dropped.
+func (a *Frame) Resample(key string, period Period) *Resample {
+	return &Resample{
+		frame:  a,
+		key:    key,
+		period: period,
+		start:  time.Monday,
+	}
+}
+
+// Location is the time zone option of the periods.
+// By default, the location of each time is used.
+func (a *Resample) Location(o *time.Location) *Resample {
+	a.loc = o
+	return a
+}
+
+// WeekStart is the first day of weeks, Monday by default.
+func (a *Resample) WeekStart(o time.Weekday) *Resample {
+	a.start = o
+	return a
+}
+
+// Fill is the upsampling option, which generates the missing periods between the first and the last.
+// The aggregate functions are applied to empty lists for the missing periods.
+func (a *Resample) Fill(o bool) *Resample {
+	a.fill = o
+	return a
+}
+
+// Apply applies the aggregate function to resample a.
+func (a *Resample) Apply(key string, name string, f func(List) Value) *Resample {
+	a.keys = append(a.keys, key)
+	a.names = append(a.names, name)
+	a.funcs = append(a.funcs, f)
+	return a
+}
+
+// Do does the resample.
+// The first list is the start times of the periods, named by the key.
+// It panics if any key is not found or the names are duplicate.
+func (a *Resample) Do() *Frame {
+	frame, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the resample, or returns an error if any key is not found or the names are duplicate.
+func (a *Resample) TryDo() (*Frame, error) {
+	frame, err := TryNewFrame(append([]string{a.key}, a.names...)...)
+	if err != nil {
+		return nil, err
+	}
+	frame.workers = a.frame.workers
+	tlist, err := a.frame.TryGet(a.key)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := a.frame.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var starts []time.Time
+	// data is keyed by the instants, since equal times may have different locations.
+	data := make(map[[2]int64][]int)
+	for i, v := range tlist {
+		t, ok := v.(Time)
+		if !ok {
+			continue
+		}
+		s := a.period.Truncate(time.Time(t), a.loc, a.start)
+		if _, ok := data[instant(s)]; !ok {
+			starts = append(starts, s)
+		}
+		data[instant(s)] = append(data[instant(s)], i)
+	}
+	sort.Slice(starts, func(x, y int) bool {
+		return starts[x].Before(starts[y])
+	})
+	if a.fill && len(starts) > 0 {
+		first, last := starts[0], starts[len(starts)-1]
+		starts = starts[:0]
+		for k := 0; ; k++ {
+			s := a.period.Truncate(a.period.Add(first, k), a.loc, a.start)
+			if s.After(last) {
+				break
+			}
+			starts = append(starts, s)
+		}
+	}
+
+	for _, s := range starts {
+		is := data[instant(s)]
+		frame.lists[0] = append(frame.lists[0], Time(s))
+		for j, list := range lists {
+			l := make(List, len(is))
+			for k, i := range is {
+				l[k] = list[i]
+			}
+			frame.lists[j+1] = append(frame.lists[j+1], a.funcs[j](l))
+		}
+	}
+	return frame, nil
+}
+
+// instant returns the key of the instant of time t.
+func instant(t time.Time) [2]int64 {
+	return [2]int64{t.Unix(), int64(t.Nanosecond())}
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	h, mi, s := t.Clock()
+	first := time.Date(y, m+time.Month(n), 1, h, mi, s, t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
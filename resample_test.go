@@ -0,0 +1,94 @@
+package dt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func date(y int, m time.Month, d int) dt.Time {
+	return dt.Time(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+func TestPeriod(t *testing.T) {
+	x := time.Date(2020, 1, 31, 13, 45, 10, 0, time.UTC)
+	for _, c := range []struct {
+		period dt.Period
+		start  time.Time
+		next   time.Time
+	}{
+		{dt.Minute, time.Date(2020, 1, 31, 13, 45, 0, 0, time.UTC), time.Date(2020, 1, 31, 13, 46, 10, 0, time.UTC)},
+		{dt.Hour, time.Date(2020, 1, 31, 13, 0, 0, 0, time.UTC), time.Date(2020, 1, 31, 14, 45, 10, 0, time.UTC)},
+		{dt.Day, time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 1, 13, 45, 10, 0, time.UTC)},
+		{dt.Week, time.Date(2020, 1, 27, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 7, 13, 45, 10, 0, time.UTC)},
+		{dt.Month, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 29, 13, 45, 10, 0, time.UTC)},
+		{dt.Quarter, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 4, 30, 13, 45, 10, 0, time.UTC)},
+		{dt.Year, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 31, 13, 45, 10, 0, time.UTC)},
+	} {
+		if got := c.period.Truncate(x, nil, time.Monday); !got.Equal(c.start) {
+			t.Errorf("got %v of period %v, want %v", got, c.period, c.start)
+		}
+		if got := c.period.Add(x, 1); !got.Equal(c.next) {
+			t.Errorf("got %v of period %v, want %v", got, c.period, c.next)
+		}
+	}
+	if got := dt.Week.Truncate(x, nil, time.Sunday); !got.Equal(time.Date(2020, 1, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v, want the Sunday", got)
+	}
+	loc := time.FixedZone("X", 12*3600)
+	if got := dt.Day.Truncate(x, loc, time.Monday); !got.Equal(time.Date(2020, 2, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("got %v, want the day in the location", got)
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	start := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC)
+	want := dt.List{date(2020, 1, 31), date(2020, 2, 29), date(2020, 3, 31), date(2020, 4, 30)}
+	assertList(t, want, dt.DateRange(start, end, dt.Month))
+	if got := dt.DateRange(end, start, dt.Day); len(got) != 0 {
+		t.Errorf("got %v, want an empty range", got)
+	}
+}
+
+func TestResample(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("t", dt.List{date(2020, 1, 1), date(2020, 1, 15), nil, date(2020, 3, 2), dt.String("x")}).
+		Add("x", dt.List{dt.Int(1), dt.Int(2), dt.Int(4), dt.Int(8), dt.Int(16)})
+	got := frame.Resample("t", dt.Month).Apply("x", "sum", dt.Sum).Apply("x", "count", dt.Count).Do()
+	want := dt.NewFrame().
+		Add("t", dt.List{date(2020, 1, 1), date(2020, 3, 1)}).
+		Add("sum", dt.List{dt.Int(3), dt.Int(8)}).
+		Add("count", dt.List{dt.Int(2), dt.Int(1)})
+	dttest.AssertFrameEqual(t, want, got)
+
+	got = frame.Resample("t", dt.Month).Fill(true).Apply("x", "count", dt.Count).Do()
+	want = dt.NewFrame().
+		Add("t", dt.List{date(2020, 1, 1), date(2020, 2, 1), date(2020, 3, 1)}).
+		Add("count", dt.List{dt.Int(2), dt.Int(0), dt.Int(1)})
+	dttest.AssertFrameEqual(t, want, got)
+
+	if _, err := frame.Resample("t", dt.Month).Apply("y", "y", dt.Sum).TryDo(); err == nil {
+		t.Error("missing key is resampled")
+	}
+	if _, err := frame.Resample("t", dt.Month).Apply("x", "t", dt.Sum).TryDo(); err == nil {
+		t.Error("duplicate names are resampled")
+	}
+}
+
+func TestResampleLocation(t *testing.T) {
+	loc := time.FixedZone("X", 3600)
+	frame := dt.NewFrame().
+		Add("t", dt.List{
+			dt.Time(time.Date(2020, 1, 1, 0, 30, 0, 0, loc)),
+			dt.Time(time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC)),
+		}).
+		Add("x", dt.List{dt.Int(1), dt.Int(2)})
+	got := frame.Resample("t", dt.Day).Location(loc).Apply("x", "sum", dt.Sum).Do()
+	want := dt.NewFrame().
+		Add("t", dt.List{dt.Time(time.Date(2020, 1, 1, 0, 0, 0, 0, loc))}).
+		Add("sum", dt.List{dt.Int(3)})
+	dttest.AssertFrameEqual(t, want, got)
+}
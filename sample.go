@@ -0,0 +1,238 @@
+package dt
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Sample is the sample option.
+type Sample struct {
+	frame   *Frame
+	n       int
+	frac    float64
+	replace bool
+	weights string
+	keys    []string
+	seed    int64
+}
+
+// Sample returns the sample option of n rows.
+func (a *Frame) Sample(n int) *Sample {
+	if n < 0 {
+		panic("dt: invalid sample size: " + strconv.Itoa(n))
+	}
+	return &Sample{
+		frame: a,
+		n:     n,
+		frac:  -1,
+		seed:  time.Now().UnixNano(),
+	}
+}
+
+// Frac samples the fraction of rows instead of n rows.
+func (a *Sample) Frac(o float64) *Sample {
+	if o < 0 || math.IsNaN(o) {
+		panic("dt: invalid sample fraction: " + strconv.FormatFloat(o, 'g', -1, 64))
+	}
+	a.frac = o
+	return a
+}
+
+// Replace is the with replacement option.
+func (a *Sample) Replace(o bool) *Sample {
+	a.replace = o
+	return a
+}
+
+// Weights sets the weights list by key. NA and non-positive weights are never sampled.
+func (a *Sample) Weights(key string) *Sample {
+	a.weights = key
+	return a
+}
+
+// Seed sets the seed of the random source, for reproducible samples.
+func (a *Sample) Seed(o int64) *Sample {
+	a.seed = o
+	return a
+}
+
+// By samples each group of the keys, for stratified samples.
+func (a *Sample) By(key string, keys ...string) *Sample {
+	a.keys = append([]string{key}, keys...)
+	return a
+}
+
+// Do does the sample.
+// It panics if any key is not found or the sample is larger than the population.
+func (a *Sample) Do() *Frame {
+	frame, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the sample,
+// or returns an error if any key is not found or the sample is larger than the population.
+func (a *Sample) TryDo() (*Frame, error) {
+	var weights List
+	if a.weights != "" {
+		var err error
+		if weights, err = a.frame.TryGet(a.weights); err != nil {
+			return nil, err
+		}
+	}
+	groups := [][]int{seq(a.frame.Len())}
+	if len(a.keys) > 0 {
+		g, err := a.frame.TryGroupBy(a.keys[0], a.keys[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		if groups, err = g.groups(); err != nil {
+			return nil, err
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(a.seed))
+	var is []int
+	for _, g := range groups {
+		n := a.n
+		if a.frac >= 0 {
+			n = int(math.Round(a.frac * float64(len(g))))
+		}
+		xs, err := sample(rnd, g, n, a.replace, weights)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, xs...)
+	}
+	return a.frame.take(is), nil
+}
+
+// Shuffle shuffles the rows of frame a by the seed.
+func (a *Frame) Shuffle(seed int64) *Frame {
+	a.ownAll()
+	s := sorter{
+		frame: a,
+	}
+	rand.New(rand.NewSource(seed)).Shuffle(a.Len(), s.Swap)
+	return a
+}
+
+// Split shuffles the rows by the seed, and splits them into frames by the fractions.
+// The fractions are normalized by their sum.
+// It panics if any fraction is negative, or the sum of the fractions is not positive and finite.
+func (a *Frame) Split(seed int64, fracs ...float64) []*Frame {
+	s := 0.0
+	for _, f := range fracs {
+		if f < 0 || math.IsNaN(f) {
+			panic("dt: invalid split fraction: " + strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		s += f
+	}
+	if s == 0 || math.IsInf(s, 0) {
+		panic("dt: invalid split fractions: " + strconv.FormatFloat(s, 'g', -1, 64))
+	}
+	n := a.Len()
+	is := seq(n)
+	rand.New(rand.NewSource(seed)).Shuffle(n, func(i, j int) {
+		is[i], is[j] = is[j], is[i]
+	})
+	frames := make([]*Frame, len(fracs))
+	c, k := 0.0, 0
+	for j, f := range fracs {
+		c += f
+		m := int(math.Round(c / s * float64(n)))
+		if j == len(fracs)-1 {
+			m = n
+		}
+		frames[j] = a.take(is[k:m])
+		k = m
+	}
+	return frames
+}
+
+func sample(rnd *rand.Rand, is []int, n int, replace bool, weights List) ([]int, error) {
+	if !replace && n > len(is) {
+		return nil, errors.New("dt: sample larger than the population: " + strconv.Itoa(n))
+	}
+	if weights == nil {
+		xs := make([]int, n)
+		if replace {
+			for k := range xs {
+				xs[k] = is[rnd.Intn(len(is))]
+			}
+			return xs, nil
+		}
+		ys := append([]int(nil), is...)
+		for k := range xs {
+			r := k + rnd.Intn(len(ys)-k)
+			ys[k], ys[r] = ys[r], ys[k]
+			xs[k] = ys[k]
+		}
+		return xs, nil
+	}
+
+	ws := make([]float64, len(is))
+	total := 0.0
+	for k, i := range is {
+		if w := weights[i]; !IsNA(w) && w.Number() > 0 {
+			ws[k] = w.Number()
+			total += ws[k]
+		}
+	}
+	if n > 0 && total == 0 {
+		return nil, errors.New("dt: sample with zero weights")
+	}
+	if replace {
+		cs := make([]float64, len(ws))
+		c := 0.0
+		for k, w := range ws {
+			c += w
+			cs[k] = c
+		}
+		xs := make([]int, n)
+		for k := range xs {
+			x := rnd.Float64() * c
+			xs[k] = is[sort.Search(len(cs), func(r int) bool {
+				return cs[r] > x
+			})]
+		}
+		return xs, nil
+	}
+
+	// Efraimidis-Spirakis: take the n largest u^(1/w).
+	type item struct {
+		i int
+		k float64
+	}
+	items := make([]item, 0, len(is))
+	for k, i := range is {
+		if ws[k] > 0 {
+			items = append(items, item{i, math.Log(rnd.Float64()) / ws[k]})
+		}
+	}
+	if n > len(items) {
+		return nil, errors.New("dt: sample larger than the positive weights: " + strconv.Itoa(n))
+	}
+	sort.SliceStable(items, func(x, y int) bool {
+		return items[x].k > items[y].k
+	})
+	xs := make([]int, n)
+	for k := range xs {
+		xs[k] = items[k].i
+	}
+	return xs, nil
+}
+
+func seq(n int) []int {
+	is := make([]int, n)
+	for i := range is {
+		is[i] = i
+	}
+	return is
+}
@@ -0,0 +1,102 @@
+package dt_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func numbers(n int) *dt.Frame {
+	xs := make(dt.List, n)
+	gs := make(dt.List, n)
+	for i := range xs {
+		xs[i] = dt.Int(i)
+		gs[i] = dt.String("ab"[i%2 : i%2+1])
+	}
+	return dt.NewFrame().Add("x", xs).Add("g", gs)
+}
+
+// distinct returns the number of distinct values of the list.
+func distinct(l dt.List) int {
+	m := make(map[dt.Value]bool)
+	for _, v := range l {
+		m[v] = true
+	}
+	return len(m)
+}
+
+func TestSample(t *testing.T) {
+	frame := numbers(20)
+	a := frame.Sample(5).Seed(1).Do()
+	b := frame.Sample(5).Seed(1).Do()
+	dttest.AssertFrameEqual(t, a, b)
+	if a.Len() != 5 || distinct(a.Get("x")) != 5 {
+		t.Errorf("got %v, want 5 distinct rows", a.Get("x"))
+	}
+	if got := frame.Sample(0).Frac(0.25).Seed(1).Do().Len(); got != 5 {
+		t.Errorf("got %v rows, want 5", got)
+	}
+	if got := frame.Sample(30).Replace(true).Seed(1).Do().Len(); got != 30 {
+		t.Errorf("got %v rows, want 30", got)
+	}
+	if _, err := frame.Sample(30).TryDo(); err == nil {
+		t.Error("a sample larger than the population is taken")
+	}
+
+	by := frame.Sample(3).By("g").Seed(1).Do()
+	for _, g := range []string{"a", "b"} {
+		n := 0
+		for _, v := range by.Get("g") {
+			if v == dt.String(g) {
+				n++
+			}
+		}
+		if n != 3 {
+			t.Errorf("got %v rows of group %v, want 3", n, g)
+		}
+	}
+}
+
+func TestSampleWeights(t *testing.T) {
+	frame := dt.NewFrame().
+		Add("x", dt.List{dt.Int(0), dt.Int(1), dt.Int(2), dt.Int(3)}).
+		Add("w", dt.List{dt.Number(0), dt.Number(1), nil, dt.Number(-1)})
+	for seed := int64(0); seed < 10; seed++ {
+		got := frame.Sample(10).Replace(true).Weights("w").Seed(seed).Do()
+		for _, v := range got.Get("x") {
+			if v != dt.Int(1) {
+				t.Fatalf("got %v, want only the row of the positive weight", got.Get("x"))
+			}
+		}
+	}
+	got := frame.Sample(1).Weights("w").Seed(1).Do()
+	dttest.AssertFrameEqual(t, frame.Slice(1, 2), got)
+	if _, err := frame.Sample(2).Weights("w").TryDo(); err == nil {
+		t.Error("a sample larger than the positive weights is taken")
+	}
+	if _, err := frame.Sample(1).Weights("y").TryDo(); err == nil {
+		t.Error("a sample of missing weights is taken")
+	}
+}
+
+func TestShuffleSplit(t *testing.T) {
+	frame := numbers(10).Shuffle(1)
+	if got := frame.Get("x"); distinct(got) != 10 {
+		t.Errorf("got %v, want a permutation", got)
+	}
+	dttest.AssertFrameEqual(t, numbers(10), frame, dttest.IgnoreRowOrder())
+
+	frames := numbers(10).Split(1, 3, 1)
+	if len(frames) != 2 || frames[0].Len() != 8 || frames[1].Len() != 2 {
+		t.Fatalf("got %v frames, want 8 and 2 rows", len(frames))
+	}
+	dttest.AssertFrameEqual(t, numbers(10), frames[0].Concat(frames[1]), dttest.IgnoreRowOrder())
+
+	defer func() {
+		if recover() == nil {
+			t.Error("frame is split by zero fractions")
+		}
+	}()
+	numbers(10).Split(1, 0, 0)
+}
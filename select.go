@@ -0,0 +1,245 @@
+package dt
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Selector selects the keys of a frame, in order of the columns unless noted otherwise.
+type Selector func(frame *Frame) ([]string, error)
+
+// Keys selects the keys in the given order.
+// The selection fails if any key is not found.
+func Keys(keys ...string) Selector {
+	return func(frame *Frame) ([]string, error) {
+		if err := frame.Check(keys...); err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+}
+
+// Regexp selects the keys matching the regular expression.
+// It panics if the expression is invalid.
+func Regexp(expr string) Selector {
+	re := regexp.MustCompile(expr)
+	return match(re.MatchString)
+}
+
+// Prefix selects the keys with the prefix.
+func Prefix(prefix string) Selector {
+	return match(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// Suffix selects the keys with the suffix.
+func Suffix(suffix string) Selector {
+	return match(func(key string) bool {
+		return strings.HasSuffix(key, suffix)
+	})
+}
+
+// OfType selects the keys whose lists have values other than NA,
+// and all of them have the types of the example values, such as:
+//
+//	frame.PickWith(dt.OfType(dt.Int(0), dt.Decimal{}, dt.Number(0)))
+func OfType(examples ...Value) Selector {
+	types := make(map[reflect.Type]bool, len(examples))
+	for _, v := range examples {
+		types[reflect.TypeOf(v)] = true
+	}
+	return func(frame *Frame) ([]string, error) {
+		var keys []string
+		for _, key := range frame.Keys() {
+			ok := false
+			for _, v := range frame.Get(key) {
+				if IsNA(v) {
+					continue
+				}
+				if ok = types[reflect.TypeOf(v)]; !ok {
+					break
+				}
+			}
+			if ok {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+}
+
+// Range selects the keys of the columns at positions i to j, exclusive.
+// Negative positions count from the end, as Slice does.
+// The selection fails if the positions are out of range.
+func Range(i, j int) Selector {
+	return func(frame *Frame) ([]string, error) {
+		keys := frame.Keys()
+		n := len(keys)
+		x, y := i, j
+		if x < 0 {
+			x += n
+		}
+		if y < 0 {
+			y += n
+		}
+		if x < 0 || x > n {
+			return nil, &IndexError{
+				Index: i,
+				Len:   n,
+			}
+		}
+		if y < x || y > n {
+			return nil, &IndexError{
+				Index: j,
+				Len:   n,
+			}
+		}
+		return keys[x:y], nil
+	}
+}
+
+func match(f func(string) bool) Selector {
+	return func(frame *Frame) ([]string, error) {
+		var keys []string
+		for _, key := range frame.Keys() {
+			if f(key) {
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	}
+}
+
+// Select returns the keys selected by the selector.
+// It panics if the selection fails.
+func (a *Frame) Select(s Selector) []string {
+	keys, err := s(a)
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+// TrySelect returns the keys selected by the selector, or returns an error if the selection fails.
+func (a *Frame) TrySelect(s Selector) ([]string, error) {
+	return s(a)
+}
+
+// PickWith picks the lists selected by the selector and returns a new frame.
+// It panics if the selection fails.
+func (a *Frame) PickWith(s Selector) *Frame {
+	b, err := a.TryPickWith(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryPickWith picks the lists selected by the selector and returns a new frame,
+// or returns an error if the selection fails.
+func (a *Frame) TryPickWith(s Selector) (*Frame, error) {
+	keys, err := s(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return NewFrame().Workers(a.workers), nil
+	}
+	return a.TryPick(keys[0], keys[1:]...)
+}
+
+// DelWith deletes the lists selected by the selector.
+// It panics if the selection fails.
+func (a *Frame) DelWith(s Selector) *Frame {
+	if _, err := a.TryDelWith(s); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryDelWith deletes the lists selected by the selector, or returns an error if the selection fails.
+func (a *Frame) TryDelWith(s Selector) (*Frame, error) {
+	keys, err := s(a)
+	if err != nil {
+		return nil, err
+	}
+	return a.Del(keys...), nil
+}
+
+// FillNAWith fills NA value of the lists selected by the selector with v.
+// It panics if the selection fails.
+func (a *Frame) FillNAWith(value Value, s Selector) *Frame {
+	if _, err := a.TryFillNAWith(value, s); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryFillNAWith fills NA value of the lists selected by the selector with v,
+// or returns an error if the selection fails.
+func (a *Frame) TryFillNAWith(value Value, s Selector) (*Frame, error) {
+	keys, err := s(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return a, nil
+	}
+	return a.TryFillNA(value, keys...)
+}
+
+// RenameWith renames all the keys by function f.
+// It panics if the new keys collide.
+func (a *Frame) RenameWith(f func(string) string) *Frame {
+	if _, err := a.TryRenameWith(f); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryRenameWith renames all the keys by function f, or returns an error if the new keys collide.
+func (a *Frame) TryRenameWith(f func(string) string) (*Frame, error) {
+	keys := a.Keys()
+	for j, key := range keys {
+		keys[j] = f(key)
+	}
+	return a.rename(keys)
+}
+
+// RenameMap renames the keys of the map to their values at once, so keys can be swapped.
+// It panics if any old key is not found or the new keys collide.
+func (a *Frame) RenameMap(m map[string]string) *Frame {
+	if _, err := a.TryRenameMap(m); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// TryRenameMap renames the keys of the map to their values at once,
+// or returns an error if any old key is not found or the new keys collide.
+func (a *Frame) TryRenameMap(m map[string]string) (*Frame, error) {
+	keys := a.Keys()
+	for old, new := range m {
+		j, ok := a.index[old]
+		if !ok {
+			return nil, keyNotFound(old)
+		}
+		keys[j] = new
+	}
+	return a.rename(keys)
+}
+
+// rename renames the keys of the columns in order, and leaves frame a unchanged if they collide.
+func (a *Frame) rename(keys []string) (*Frame, error) {
+	index := make(map[string]int, len(keys))
+	for j, key := range keys {
+		if _, ok := index[key]; ok {
+			return nil, keyExists(key)
+		}
+		index[key] = j
+	}
+	a.index = index
+	return a, nil
+}
@@ -0,0 +1,123 @@
+package dt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func selectFrame() *dt.Frame {
+	return dttest.Frame(`
+		id | q1_sales | q1_cost | q2_sales | note | empty
+		1  | 1.5      | 1       | 2.5      | a    | NA
+		2  | NA       | 2       | 3        | b    | NA
+	`)
+}
+
+func TestSelect(t *testing.T) {
+	frame := selectFrame()
+	cases := []struct {
+		name string
+		s    dt.Selector
+		want []string
+	}{
+		{"keys", dt.Keys("note", "id"), []string{"note", "id"}},
+		{"regexp", dt.Regexp(`^q\d_sales$`), []string{"q1_sales", "q2_sales"}},
+		{"prefix", dt.Prefix("q1_"), []string{"q1_sales", "q1_cost"}},
+		{"suffix", dt.Suffix("_sales"), []string{"q1_sales", "q2_sales"}},
+		{"type", dt.OfType(dt.Int(0)), []string{"id", "q1_cost"}},
+		{"types", dt.OfType(dt.Int(0), dt.Decimal{}), []string{"id", "q1_sales", "q1_cost", "q2_sales"}},
+		{"range", dt.Range(1, 3), []string{"q1_sales", "q1_cost"}},
+		{"negative range", dt.Range(-2, -1), []string{"note"}},
+		{"none", dt.Prefix("x"), nil},
+	}
+	for _, c := range cases {
+		got := frame.Select(c.s)
+		if strings.Join(got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	for _, s := range []dt.Selector{dt.Keys("id", "x"), dt.Range(0, 7), dt.Range(-7, 1), dt.Range(3, 2)} {
+		if _, err := frame.TrySelect(s); err == nil {
+			t.Error("an invalid selection is accepted")
+		}
+	}
+}
+
+func TestPickDelWith(t *testing.T) {
+	frame := selectFrame()
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		q2_sales | q1_sales
+		2.5      | 1.5
+		3        | NA
+	`), frame.PickWith(dt.Keys("q2_sales", "q1_sales")))
+	if got := frame.PickWith(dt.Prefix("x")); got.Len() != 0 || len(got.Keys()) != 0 {
+		t.Errorf("got %v, want an empty frame", got)
+	}
+
+	frame.DelWith(dt.Regexp("^q"))
+	if got := strings.Join(frame.Keys(), ","); got != "id,note,empty" {
+		t.Errorf("got keys %v", got)
+	}
+	if _, err := frame.TryDelWith(dt.Keys("x")); err == nil {
+		t.Error("a key not found is deleted")
+	}
+}
+
+func TestFillNAWith(t *testing.T) {
+	frame := selectFrame().FillNAWith(dt.Int(0), dt.Suffix("_sales"))
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		id | q1_sales | q1_cost | q2_sales | note | empty
+		1  | 1.5      | 1       | 2.5      | a    | NA
+		2  | 0        | 2       | 3        | b    | NA
+	`), frame)
+
+	// an empty selection fills nothing.
+	got, err := frame.TryFillNAWith(dt.Int(0), dt.Prefix("x"))
+	if err != nil || got != frame {
+		t.Fatalf("got %v, %v, want the frame", got, err)
+	}
+	if !dt.IsNA(frame.Get("empty")[0]) {
+		t.Error("a list not selected is filled")
+	}
+}
+
+func TestRenameWith(t *testing.T) {
+	frame := selectFrame().RenameWith(strings.ToUpper)
+	if got := strings.Join(frame.Keys(), ","); got != "ID,Q1_SALES,Q1_COST,Q2_SALES,NOTE,EMPTY" {
+		t.Errorf("got keys %v", got)
+	}
+
+	frame = selectFrame()
+	if _, err := frame.TryRenameWith(func(key string) string {
+		return strings.TrimPrefix(strings.TrimPrefix(key, "q1_"), "q2_")
+	}); err == nil {
+		t.Error("colliding keys are accepted")
+	}
+	// the frame is unchanged on errors.
+	if got := strings.Join(frame.Keys(), ","); got != "id,q1_sales,q1_cost,q2_sales,note,empty" {
+		t.Errorf("got keys %v", got)
+	}
+}
+
+func TestRenameMap(t *testing.T) {
+	frame := selectFrame().RenameMap(map[string]string{
+		"q1_sales": "q2_sales",
+		"q2_sales": "q1_sales",
+	})
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		q2_sales | q1_sales
+		1.5      | 2.5
+		NA       | 3
+	`), frame.Pick("q2_sales", "q1_sales"))
+
+	if _, err := frame.TryRenameMap(map[string]string{"note": "id"}); err == nil {
+		t.Error("colliding keys are accepted")
+	}
+	if _, err := frame.TryRenameMap(map[string]string{"x": "y"}); err == nil {
+		t.Error("a key not found is renamed")
+	}
+}
@@ -0,0 +1,323 @@
+package dt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"time"
+)
+
+const (
+	tagNil byte = iota
+	tagNull
+	tagNumber
+	tagString
+	tagBool
+	tagInt
+	tagDecimal
+	tagTime
+	tagCategory
+)
+
+// spill is a temporary file of rows, which is opened only while it is written or read.
+// Categories are written as their codes in the dictionaries of dicts,
+// values of other types than the builtin ones are written as strings,
+// and times keep their zone names and offsets but not their locations.
+type spill struct {
+	name  string
+	dicts *spillDicts
+}
+
+// spillDicts is the table of the category dictionaries shared by spills.
+type spillDicts struct {
+	list  []*Categories
+	index map[*Categories]int
+}
+
+// id returns the index of the dictionary, which is added if it is not found.
+func (a *spillDicts) id(dict *Categories) int {
+	if i, ok := a.index[dict]; ok {
+		return i
+	}
+	if a.index == nil {
+		a.index = make(map[*Categories]int)
+	}
+	a.index[dict] = len(a.list)
+	a.list = append(a.list, dict)
+	return len(a.list) - 1
+}
+
+// newSpill creates an empty temporary file in dir, whose categories are written by dicts.
+func newSpill(dir string, dicts *spillDicts) (*spill, error) {
+	f, err := ioutil.TempFile(dir, "dt-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &spill{
+		name:  f.Name(),
+		dicts: dicts,
+	}, nil
+}
+
+// writer opens the spill to append rows, which must be closed.
+func (a *spill) writer() (*spillWriter, error) {
+	f, err := os.OpenFile(a.name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &spillWriter{
+		file:  f,
+		w:     bufio.NewWriter(f),
+		dicts: a.dicts,
+	}, nil
+}
+
+// reader opens the spill to read rows from the beginning, which must be closed.
+func (a *spill) reader() (*spillReader, error) {
+	f, err := os.Open(a.name)
+	if err != nil {
+		return nil, err
+	}
+	return &spillReader{
+		file:  f,
+		r:     bufio.NewReader(f),
+		dicts: a.dicts,
+	}, nil
+}
+
+// remove removes the temporary file.
+func (a *spill) remove() {
+	os.Remove(a.name)
+}
+
+// spillWriter writes rows to a spill.
+type spillWriter struct {
+	file  *os.File
+	w     *bufio.Writer
+	dicts *spillDicts
+	buf   [binary.MaxVarintLen64]byte
+}
+
+// writeRows writes rows i to j of lists.
+func (a *spillWriter) writeRows(lists []List, i, j int) error {
+	for ; i < j; i++ {
+		for _, list := range lists {
+			if err := a.write(list[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeRow writes a row.
+func (a *spillWriter) writeRow(row []Value) error {
+	for _, v := range row {
+		if err := a.write(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *spillWriter) write(v Value) error {
+	switch x := v.(type) {
+	case nil:
+		return a.w.WriteByte(tagNil)
+	case Null:
+		return a.w.WriteByte(tagNull)
+	case Number:
+		a.w.WriteByte(tagNumber)
+		return a.writeUint64(math.Float64bits(float64(x)))
+	case Bool:
+		a.w.WriteByte(tagBool)
+		if x {
+			return a.w.WriteByte(1)
+		}
+		return a.w.WriteByte(0)
+	case Int:
+		a.w.WriteByte(tagInt)
+		_, err := a.w.Write(a.buf[:binary.PutVarint(a.buf[:], int64(x))])
+		return err
+	case Decimal:
+		a.w.WriteByte(tagDecimal)
+		a.w.Write(a.buf[:binary.PutVarint(a.buf[:], x.coef)])
+		return a.w.WriteByte(x.scale)
+	case Time:
+		t := time.Time(x)
+		name, offset := t.Zone()
+		a.w.WriteByte(tagTime)
+		a.w.Write(a.buf[:binary.PutVarint(a.buf[:], t.Unix())])
+		a.w.Write(a.buf[:binary.PutVarint(a.buf[:], int64(t.Nanosecond()))])
+		a.w.Write(a.buf[:binary.PutVarint(a.buf[:], int64(offset))])
+		return a.writeBytes([]byte(name))
+	case *Category:
+		a.w.WriteByte(tagCategory)
+		a.w.Write(a.buf[:binary.PutUvarint(a.buf[:], uint64(a.dicts.id(x.dict)))])
+		_, err := a.w.Write(a.buf[:binary.PutUvarint(a.buf[:], uint64(x.code))])
+		return err
+	default:
+		a.w.WriteByte(tagString)
+		return a.writeBytes([]byte(v.String()))
+	}
+}
+
+func (a *spillWriter) writeUint64(x uint64) error {
+	binary.LittleEndian.PutUint64(a.buf[:8], x)
+	_, err := a.w.Write(a.buf[:8])
+	return err
+}
+
+func (a *spillWriter) writeBytes(bs []byte) error {
+	a.w.Write(a.buf[:binary.PutUvarint(a.buf[:], uint64(len(bs)))])
+	_, err := a.w.Write(bs)
+	return err
+}
+
+// close flushes the written rows and closes the file.
+func (a *spillWriter) close() error {
+	err := a.w.Flush()
+	if e := a.file.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// spillReader reads rows from a spill.
+type spillReader struct {
+	file  *os.File
+	r     *bufio.Reader
+	dicts *spillDicts
+	buf   [8]byte
+}
+
+// close closes the file.
+func (a *spillReader) close() error {
+	return a.file.Close()
+}
+
+// readRow reads a row, or returns io.EOF if there are no more rows.
+func (a *spillReader) readRow(row []Value) error {
+	for j := range row {
+		v, err := a.read()
+		if err != nil {
+			if err == io.EOF && j > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		row[j] = v
+	}
+	return nil
+}
+
+func (a *spillReader) read() (Value, error) {
+	tag, err := a.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case tagNil:
+		return nil, nil
+	case tagNull:
+		return Null{}, nil
+	case tagNumber:
+		if _, err := io.ReadFull(a.r, a.buf[:]); err != nil {
+			return nil, eof(err)
+		}
+		return Number(math.Float64frombits(binary.LittleEndian.Uint64(a.buf[:]))), nil
+	case tagBool:
+		b, err := a.r.ReadByte()
+		return Bool(b != 0), eof(err)
+	case tagInt:
+		x, err := binary.ReadVarint(a.r)
+		return Int(x), eof(err)
+	case tagDecimal:
+		x, err := binary.ReadVarint(a.r)
+		if err != nil {
+			return nil, eof(err)
+		}
+		s, err := a.r.ReadByte()
+		return Decimal{
+			coef:  x,
+			scale: s,
+		}, eof(err)
+	case tagTime:
+		var xs [3]int64
+		for k := range xs {
+			if xs[k], err = binary.ReadVarint(a.r); err != nil {
+				return nil, eof(err)
+			}
+		}
+		name, err := a.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		// times in Local or UTC are restored to the locations.
+		t := time.Unix(xs[0], xs[1])
+		switch n, o := t.Zone(); {
+		case string(name) == "UTC" && xs[2] == 0:
+			t = t.UTC()
+		case n == string(name) && o == int(xs[2]):
+		default:
+			t = t.In(time.FixedZone(string(name), int(xs[2])))
+		}
+		return Time(t), nil
+	case tagString:
+		bs, err := a.readBytes()
+		return String(bs), err
+	case tagCategory:
+		var xs [2]uint64
+		for k := range xs {
+			if xs[k], err = binary.ReadUvarint(a.r); err != nil {
+				return nil, eof(err)
+			}
+		}
+		if xs[0] < uint64(len(a.dicts.list)) {
+			dict := a.dicts.list[xs[0]]
+			if xs[1] < uint64(dict.Len()) {
+				return dict.levels[xs[1]], nil
+			}
+		}
+	}
+	return nil, errors.New("dt: invalid spill data")
+}
+
+func (a *spillReader) readBytes() ([]byte, error) {
+	n, err := binary.ReadUvarint(a.r)
+	if err != nil {
+		return nil, eof(err)
+	}
+	bs := make([]byte, n)
+	_, err = io.ReadFull(a.r, bs)
+	return bs, eof(err)
+}
+
+// eof converts io.EOF in the middle of a value to io.ErrUnexpectedEOF.
+func eof(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// sizeOf returns the approximate memory size of value v in bytes.
+func sizeOf(v Value) int64 {
+	switch x := v.(type) {
+	case String:
+		return 32 + int64(len(x))
+	case Time:
+		return 40
+	case Decimal:
+		return 32
+	}
+	return 24
+}
@@ -0,0 +1,54 @@
+package dt
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSpillTime(t *testing.T) {
+	base := time.Date(2020, 3, 4, 5, 6, 7, 8, time.UTC)
+	row := []Value{
+		Time(base),
+		Time(base.Local()),
+		Time(base.In(time.FixedZone("CST", 8*60*60))),
+		Time(base.In(time.FixedZone("", 5*60*60+30*60+17))),
+		Time(time.Date(-1000, 1, 1, 0, 0, 0, 0, time.FixedZone("", -70*60*60))),
+	}
+	s, err := newSpill("", new(spillDicts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.remove()
+	w, err := s.writer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.writeRow(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.close()
+	got := make([]Value, len(row))
+	if err := r.readRow(got); err != nil {
+		t.Fatal(err)
+	}
+	for j, v := range row {
+		x, y := time.Time(v.(Time)), time.Time(got[j].(Time))
+		if !x.Equal(y) || x.String() != y.String() {
+			t.Errorf("got %v, want %v", y, x)
+		}
+	}
+	if loc := time.Time(got[0].(Time)).Location(); loc != time.UTC {
+		t.Errorf("got location %v, want UTC", loc)
+	}
+	if err := r.readRow(got); err != io.EOF {
+		t.Errorf("got %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,263 @@
+// Package str provides the vectorized string operations of dt.List.
+// NA values are passed through as is, and other values are taken by their strings.
+package str
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ofunc/dt"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Upper converts the strings to upper case.
+func Upper(l dt.List) dt.List {
+	return mapString(l, strings.ToUpper)
+}
+
+// Lower converts the strings to lower case.
+func Lower(l dt.List) dt.List {
+	return mapString(l, strings.ToLower)
+}
+
+// Title converts the strings to title case.
+func Title(l dt.List) dt.List {
+	caser := cases.Title(language.Und)
+	return mapString(l, caser.String)
+}
+
+// Trim trims the leading and trailing characters in cutset.
+// If cutset is empty, white spaces are trimmed.
+func Trim(l dt.List, cutset string) dt.List {
+	if cutset == "" {
+		return mapString(l, strings.TrimSpace)
+	}
+	return mapString(l, func(s string) string {
+		return strings.Trim(s, cutset)
+	})
+}
+
+// PadLeft pads the strings on the left with pad to the width in runes.
+func PadLeft(l dt.List, width int, pad rune) dt.List {
+	return mapString(l, func(s string) string {
+		if n := width - utf8.RuneCountInString(s); n > 0 {
+			return strings.Repeat(string(pad), n) + s
+		}
+		return s
+	})
+}
+
+// PadRight pads the strings on the right with pad to the width in runes.
+func PadRight(l dt.List, width int, pad rune) dt.List {
+	return mapString(l, func(s string) string {
+		if n := width - utf8.RuneCountInString(s); n > 0 {
+			return s + strings.Repeat(string(pad), n)
+		}
+		return s
+	})
+}
+
+// Sub returns the substrings of runes [i, j).
+// Negative indexes count from the end, and indexes out of range are clamped.
+func Sub(l dt.List, i, j int) dt.List {
+	return mapString(l, func(s string) string {
+		rs := []rune(s)
+		n := len(rs)
+		x, y := clamp(i, n), clamp(j, n)
+		if x >= y {
+			return ""
+		}
+		return string(rs[x:y])
+	})
+}
+
+// Len returns the lengths of the strings in runes.
+func Len(l dt.List) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.Int(utf8.RuneCountInString(s))
+	})
+}
+
+// Contains checks if the strings contain sub.
+func Contains(l dt.List, sub string) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.Bool(strings.Contains(s, sub))
+	})
+}
+
+// HasPrefix checks if the strings begin with prefix.
+func HasPrefix(l dt.List, prefix string) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.Bool(strings.HasPrefix(s, prefix))
+	})
+}
+
+// HasSuffix checks if the strings end with suffix.
+func HasSuffix(l dt.List, suffix string) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.Bool(strings.HasSuffix(s, suffix))
+	})
+}
+
+// Match checks if the strings match the regexp.
+func Match(l dt.List, re *regexp.Regexp) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.Bool(re.MatchString(s))
+	})
+}
+
+// Extract extracts the first match of the regexp, and returns a list for each group.
+// If the regexp has no groups, the whole match is returned.
+// Unmatched values are nil.
+func Extract(l dt.List, re *regexp.Regexp) []dt.List {
+	n := re.NumSubexp()
+	ls := make([]dt.List, n)
+	if n == 0 {
+		ls = make([]dt.List, 1)
+	}
+	for j := range ls {
+		ls[j] = make(dt.List, len(l))
+	}
+	for i, v := range l {
+		if dt.IsNA(v) {
+			for _, x := range ls {
+				x[i] = v
+			}
+			continue
+		}
+		m := re.FindStringSubmatchIndex(v.String())
+		if m == nil {
+			continue
+		}
+		s := v.String()
+		for j, x := range ls {
+			k := j
+			if n > 0 {
+				k++
+			}
+			if m[2*k] >= 0 {
+				x[i] = dt.String(s[m[2*k]:m[2*k+1]])
+			}
+		}
+	}
+	return ls
+}
+
+// Replace replaces all old with new.
+func Replace(l dt.List, old, new string) dt.List {
+	return mapString(l, func(s string) string {
+		return strings.ReplaceAll(s, old, new)
+	})
+}
+
+// ReplaceRegexp replaces all matches of the regexp with repl, which may refer to the groups like $1.
+func ReplaceRegexp(l dt.List, re *regexp.Regexp, repl string) dt.List {
+	return mapString(l, func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	})
+}
+
+// Split splits the strings by sep into columns.
+// If n > 0, there are at most n columns and the last one is the unsplit remainder.
+// Missing parts are nil, and NA values are kept in the first column,
+// so there is at least one column unless the list is empty.
+func Split(l dt.List, sep string, n int) []dt.List {
+	parts := make([][]string, len(l))
+	m := 0
+	for i, v := range l {
+		if dt.IsNA(v) {
+			continue
+		}
+		if n > 0 {
+			parts[i] = strings.SplitN(v.String(), sep, n)
+		} else {
+			parts[i] = strings.Split(v.String(), sep)
+		}
+		if len(parts[i]) > m {
+			m = len(parts[i])
+		}
+	}
+	if m == 0 && len(l) > 0 {
+		m = 1
+	}
+	ls := make([]dt.List, m)
+	for j := range ls {
+		ls[j] = make(dt.List, len(l))
+	}
+	for i, ps := range parts {
+		if ps == nil {
+			ls[0][i] = l[i]
+		}
+		for j, p := range ps {
+			ls[j][i] = dt.String(p)
+		}
+	}
+	return ls
+}
+
+// SplitRows splits the strings by sep into rows,
+// and returns the parts and the indexes of their original rows.
+// NA values are kept as one row.
+func SplitRows(l dt.List, sep string) (dt.List, []int) {
+	r := make(dt.List, 0, len(l))
+	is := make([]int, 0, len(l))
+	for i, v := range l {
+		if dt.IsNA(v) {
+			r = append(r, v)
+			is = append(is, i)
+			continue
+		}
+		for _, p := range strings.Split(v.String(), sep) {
+			r = append(r, dt.String(p))
+			is = append(is, i)
+		}
+	}
+	return r, is
+}
+
+// Normalize normalizes the strings to the Unicode normalization form, such as norm.NFKC.
+func Normalize(l dt.List, form norm.Form) dt.List {
+	return mapString(l, form.String)
+}
+
+// Narrow converts the full-width characters to half-width.
+func Narrow(l dt.List) dt.List {
+	return mapString(l, width.Narrow.String)
+}
+
+// Widen converts the half-width characters to full-width.
+func Widen(l dt.List) dt.List {
+	return mapString(l, width.Widen.String)
+}
+
+func mapString(l dt.List, f func(string) string) dt.List {
+	return mapValue(l, func(s string) dt.Value {
+		return dt.String(f(s))
+	})
+}
+
+func mapValue(l dt.List, f func(string) dt.Value) dt.List {
+	return l.Map(func(v dt.Value) dt.Value {
+		if dt.IsNA(v) {
+			return v
+		}
+		return f(v.String())
+	})
+}
+
+func clamp(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
@@ -0,0 +1,107 @@
+package str_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/str"
+	"golang.org/x/text/unicode/norm"
+)
+
+// list returns the list of the strings, where "NA" is nil.
+func list(ss ...string) dt.List {
+	l := make(dt.List, len(ss))
+	for i, s := range ss {
+		if s != "NA" {
+			l[i] = dt.String(s)
+		}
+	}
+	return l
+}
+
+func assertList(t *testing.T, want, got dt.List) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+		return
+	}
+	for i := range want {
+		if !dt.Equal(want[i], got[i]) {
+			t.Errorf("got %v, want %v", got, want)
+			return
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	l := list(" aB ", "NA", "ｃ")
+	assertList(t, list(" AB ", "NA", "Ｃ"), str.Upper(l))
+	assertList(t, list(" ab ", "NA", "ｃ"), str.Lower(l))
+	assertList(t, list("aB", "NA", "ｃ"), str.Trim(l, ""))
+	assertList(t, list(" aB ", "NA", ""), str.Trim(l, "ｃ"))
+	assertList(t, list(" aB ", "NA", "c"), str.Narrow(l))
+	assertList(t, list(" aB ", "NA", "c"), str.Normalize(l, norm.NFKC))
+	assertList(t, list("Hello World", "NA"), str.Title(list("hello world", "NA")))
+	assertList(t, list("*ab", "abc", "NA"), str.PadLeft(list("ab", "abc", "NA"), 3, '*'))
+	assertList(t, list("ab**", "abcde"), str.PadRight(list("ab", "abcde"), 4, '*'))
+	assertList(t, list("bc", "", "NA"), str.Sub(list("abcd", "a", "NA"), 1, -1))
+	assertList(t, dt.List{dt.Int(2), dt.Int(0), nil}, str.Len(list("中文", "", "NA")))
+	assertList(t, list("a-b-c", "NA"), str.Replace(list("a b c", "NA"), " ", "-"))
+	assertList(t, list("x1", "NA"), str.ReplaceRegexp(list("1x", "NA"), regexp.MustCompile(`(\d)(\w)`), "$2$1"))
+}
+
+func TestPredicates(t *testing.T) {
+	l := list("abc", "NA", "xbz")
+	assertList(t, dt.List{dt.Bool(true), nil, dt.Bool(true)}, str.Contains(l, "b"))
+	assertList(t, dt.List{dt.Bool(true), nil, dt.Bool(false)}, str.HasPrefix(l, "ab"))
+	assertList(t, dt.List{dt.Bool(false), nil, dt.Bool(true)}, str.HasSuffix(l, "z"))
+	assertList(t, dt.List{dt.Bool(true), nil, dt.Bool(false)}, str.Match(l, regexp.MustCompile(`^a`)))
+}
+
+func TestExtract(t *testing.T) {
+	l := list("a-1", "NA", "b", "c-2")
+	ls := str.Extract(l, regexp.MustCompile(`(\w)-(\d)`))
+	if len(ls) != 2 {
+		t.Fatalf("got %v lists, want 2", len(ls))
+	}
+	assertList(t, list("a", "NA", "NA", "c"), ls[0])
+	assertList(t, list("1", "NA", "NA", "2"), ls[1])
+
+	ls = str.Extract(l, regexp.MustCompile(`\d`))
+	if len(ls) != 1 {
+		t.Fatalf("got %v lists, want 1", len(ls))
+	}
+	assertList(t, list("1", "NA", "NA", "2"), ls[0])
+}
+
+func TestSplit(t *testing.T) {
+	ls := str.Split(list("a,b,c", "NA", "d"), ",", 0)
+	if len(ls) != 3 {
+		t.Fatalf("got %v lists, want 3", len(ls))
+	}
+	assertList(t, list("a", "NA", "d"), ls[0])
+	assertList(t, list("b", "NA", "NA"), ls[1])
+	assertList(t, list("c", "NA", "NA"), ls[2])
+
+	ls = str.Split(list("a,b,c", "d"), ",", 2)
+	if len(ls) != 2 {
+		t.Fatalf("got %v lists, want 2", len(ls))
+	}
+	assertList(t, list("b,c", "NA"), ls[1])
+
+	ls = str.Split(list("NA", "NA"), ",", 0)
+	if len(ls) != 1 {
+		t.Fatalf("got %v lists, want 1", len(ls))
+	}
+	assertList(t, list("NA", "NA"), ls[0])
+	if ls := str.Split(nil, ",", 0); len(ls) != 0 {
+		t.Errorf("got %v lists, want none", len(ls))
+	}
+
+	parts, is := str.SplitRows(list("a,b", "NA", "c"), ",")
+	assertList(t, list("a", "b", "NA", "c"), parts)
+	if len(is) != 4 || is[0] != 0 || is[1] != 0 || is[2] != 1 || is[3] != 2 {
+		t.Errorf("got %v, want [0 0 1 2]", is)
+	}
+}
@@ -0,0 +1,177 @@
+package dt
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Take gathers the rows by the indexes, and returns a new frame.
+// Indexes may repeat, and negative indexes count from the end.
+// It panics if any index is out of range.
+func (a *Frame) Take(is []int) *Frame {
+	b, err := a.TryTake(is)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryTake gathers the rows by the indexes, or returns an error if any index is out of range.
+func (a *Frame) TryTake(is []int) (*Frame, error) {
+	n := a.Len()
+	xs := make([]int, len(is))
+	for k, i := range is {
+		x := i
+		if x < 0 {
+			x += n
+		}
+		if x < 0 || x >= n {
+			return nil, &IndexError{
+				Index: i,
+				Len:   n,
+			}
+		}
+		xs[k] = x
+	}
+	return a.take(xs), nil
+}
+
+// Head returns a new frame of at most the first n rows.
+func (a *Frame) Head(n int) *Frame {
+	m := a.Len()
+	if n > m {
+		n = m
+	}
+	if n < 0 {
+		n = 0
+	}
+	return a.take(seq(n))
+}
+
+// Tail returns a new frame of at most the last n rows.
+func (a *Frame) Tail(n int) *Frame {
+	m := a.Len()
+	if n > m {
+		n = m
+	}
+	if n < 0 {
+		n = 0
+	}
+	is := seq(n)
+	for k := range is {
+		is[k] += m - n
+	}
+	return a.take(is)
+}
+
+// NLargest returns a new frame of the n rows with the largest values of the key, in descending order.
+// NA values are skipped, and ties keep the original order.
+// It panics if the key is not found.
+func (a *Frame) NLargest(n int, key string) *Frame {
+	b, err := a.TryNLargest(n, key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryNLargest returns a new frame of the n rows with the largest values of the key,
+// or returns an error if the key is not found.
+func (a *Frame) TryNLargest(n int, key string) (*Frame, error) {
+	return a.nbest(n, key, func(x, y Value) bool {
+		return Less(y, x)
+	})
+}
+
+// NSmallest returns a new frame of the n rows with the smallest values of the key, in ascending order.
+// NA values are skipped, and ties keep the original order.
+// It panics if the key is not found.
+func (a *Frame) NSmallest(n int, key string) *Frame {
+	b, err := a.TryNSmallest(n, key)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryNSmallest returns a new frame of the n rows with the smallest values of the key,
+// or returns an error if the key is not found.
+func (a *Frame) TryNSmallest(n int, key string) (*Frame, error) {
+	return a.nbest(n, key, Less)
+}
+
+// nbest selects the n best rows by a bounded heap, whose root is the worst one kept.
+func (a *Frame) nbest(n int, key string, better func(Value, Value) bool) (*Frame, error) {
+	list, err := a.TryGet(key)
+	if err != nil {
+		return nil, err
+	}
+	h := &selector{
+		list:   list,
+		better: better,
+	}
+	if n > 0 {
+		for i, v := range list {
+			if IsNA(v) {
+				continue
+			}
+			if len(h.is) < n {
+				heap.Push(h, i)
+			} else if h.before(i, h.is[0]) {
+				h.is[0] = i
+				heap.Fix(h, 0)
+			}
+		}
+	}
+	sort.Slice(h.is, func(x, y int) bool {
+		return h.before(h.is[x], h.is[y])
+	})
+	return a.take(h.is), nil
+}
+
+type selector struct {
+	is     []int
+	list   List
+	better func(Value, Value) bool
+}
+
+// before reports whether row i ranks before row j.
+func (a *selector) before(i, j int) bool {
+	x, y := a.list[i], a.list[j]
+	if a.better(x, y) {
+		return true
+	}
+	if a.better(y, x) {
+		return false
+	}
+	return i < j
+}
+
+// Len is the number of elements in the collection.
+func (a *selector) Len() int {
+	return len(a.is)
+}
+
+// Less reports whether the element with
+// index i should sort before the element with index j.
+func (a *selector) Less(i, j int) bool {
+	return a.before(a.is[j], a.is[i])
+}
+
+// Swap swaps the elements with indexes i and j.
+func (a *selector) Swap(i, j int) {
+	a.is[i], a.is[j] = a.is[j], a.is[i]
+}
+
+// Push pushes the element x onto the heap.
+func (a *selector) Push(x interface{}) {
+	a.is = append(a.is, x.(int))
+}
+
+// Pop removes and returns the last element.
+func (a *selector) Pop() interface{} {
+	n := len(a.is) - 1
+	x := a.is[n]
+	a.is = a.is[:n]
+	return x
+}
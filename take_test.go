@@ -0,0 +1,75 @@
+package dt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+const scores = `
+	name | score
+	a    | 3
+	b    | NA
+	c    | 5
+	d    | 3
+	e    | 1
+`
+
+func TestTake(t *testing.T) {
+	frame := dttest.Frame(scores)
+	want := dttest.Frame(`
+		name | score
+		e    | 1
+		a    | 3
+		e    | 1
+	`)
+	dttest.AssertFrameEqual(t, want, frame.Take([]int{-1, 0, 4}))
+	if b, err := frame.TryTake([]int{5}); b != nil || !errors.Is(err, dt.ErrIndexOutOfRange) {
+		t.Errorf("got %v, want ErrIndexOutOfRange", err)
+	}
+	if _, err := frame.TryTake([]int{-6}); !errors.Is(err, dt.ErrIndexOutOfRange) {
+		t.Errorf("got %v, want ErrIndexOutOfRange", err)
+	}
+}
+
+func TestHeadTail(t *testing.T) {
+	frame := dttest.Frame(scores)
+	dttest.AssertFrameEqual(t, frame.Slice(0, 2), frame.Head(2))
+	dttest.AssertFrameEqual(t, frame.Slice(3, 5), frame.Tail(2))
+	dttest.AssertFrameEqual(t, frame, frame.Head(10))
+	dttest.AssertFrameEqual(t, frame, frame.Tail(10))
+	if n := frame.Head(-1).Len(); n != 0 {
+		t.Errorf("got %v rows, want 0", n)
+	}
+	if n := frame.Tail(-1).Len(); n != 0 {
+		t.Errorf("got %v rows, want 0", n)
+	}
+}
+
+func TestNBest(t *testing.T) {
+	frame := dttest.Frame(scores)
+	want := dttest.Frame(`
+		name | score
+		c    | 5
+		a    | 3
+		d    | 3
+	`)
+	dttest.AssertFrameEqual(t, want, frame.NLargest(3, "score"))
+	want = dttest.Frame(`
+		name | score
+		e    | 1
+		a    | 3
+	`)
+	dttest.AssertFrameEqual(t, want, frame.NSmallest(2, "score"))
+	if n := frame.NLargest(10, "score").Len(); n != 4 {
+		t.Errorf("got %v rows, want the 4 rows without NA", n)
+	}
+	if n := frame.NSmallest(0, "score").Len(); n != 0 {
+		t.Errorf("got %v rows, want 0", n)
+	}
+	if _, err := frame.TryNLargest(1, "x"); !errors.Is(err, dt.ErrKeyNotFound) {
+		t.Errorf("got %v, want ErrKeyNotFound", err)
+	}
+}
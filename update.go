@@ -0,0 +1,125 @@
+package dt
+
+// Update is the update option.
+type Update struct {
+	frame  *Frame
+	other  *Frame
+	keys   []string
+	skipNA bool
+	fill   bool
+	insert bool
+}
+
+// Update returns the option to update the rows of frame a with the rows of b matching the keys.
+// Only the lists of frame a are updated, and the last row of b wins for duplicate keys.
+func (a *Frame) Update(b *Frame, key string, keys ...string) *Update {
+	return &Update{
+		frame: a,
+		other: b,
+		keys:  append([]string{key}, keys...),
+	}
+}
+
+// Upsert is Update, which also appends the rows of b not matching frame a,
+// with nil in the lists not in b.
+func (a *Frame) Upsert(b *Frame, key string, keys ...string) *Update {
+	u := a.Update(b, key, keys...)
+	u.insert = true
+	return u
+}
+
+// CombineFirst is Update, which only fills the NA values of frame a with the values of b.
+func (a *Frame) CombineFirst(b *Frame, key string, keys ...string) *Update {
+	u := a.Update(b, key, keys...)
+	u.fill = true
+	return u
+}
+
+// SkipNA is the option to keep the values of frame a where the values of b are NA.
+func (a *Update) SkipNA(o bool) *Update {
+	a.skipNA = o
+	return a
+}
+
+// Do does the update in place.
+// It panics if any key is not found.
+func (a *Update) Do() *Frame {
+	frame, err := a.TryDo()
+	if err != nil {
+		panic(err)
+	}
+	return frame
+}
+
+// TryDo does the update in place, or returns an error if any key is not found.
+func (a *Update) TryDo() (*Frame, error) {
+	frame, other := a.frame, a.other
+	alists, err := frame.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+	blists, err := other.gets(a.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTable(blists)
+	for i, n := 0, other.Len(); i < n; i++ {
+		if g, ok := t.insert(i); !ok {
+			t.rows[g] = i
+		}
+	}
+
+	isKey := make(map[string]bool, len(a.keys))
+	for _, key := range a.keys {
+		isKey[key] = true
+	}
+	keys := frame.Keys()
+	var js []int
+	var lists []List
+	for j, key := range keys {
+		if k, ok := other.index[key]; ok && !isKey[key] {
+			js = append(js, j)
+			lists = append(lists, other.lists[k])
+		}
+	}
+
+	matched := make([]bool, len(t.rows))
+	for i, n := 0, frame.Len(); i < n; i++ {
+		g := t.find(i, alists)
+		if g < 0 {
+			continue
+		}
+		matched[g] = true
+		k := t.rows[g]
+		for c, j := range js {
+			v := lists[c][k]
+			if a.fill && !IsNA(frame.lists[j][i]) {
+				continue
+			}
+			if (a.skipNA || a.fill) && IsNA(v) {
+				continue
+			}
+			frame.own(j)[i] = v
+		}
+	}
+
+	if a.insert {
+		for j, key := range keys {
+			list := frame.own(j)
+			bl, ok := other.index[key]
+			for g, ok2 := range matched {
+				if ok2 {
+					continue
+				}
+				var v Value
+				if ok {
+					v = other.lists[bl][t.rows[g]]
+				}
+				list = append(list, v)
+			}
+			frame.lists[j] = list
+		}
+	}
+	return frame, nil
+}
@@ -0,0 +1,77 @@
+package dt_test
+
+import (
+	"testing"
+
+	"github.com/ofunc/dt"
+	"github.com/ofunc/dt/dttest"
+)
+
+func master() *dt.Frame {
+	return dttest.Frame(`
+		id | name | price | stock
+		1  | a    | 10    | 5
+		2  | b    | NA    | 6
+		3  | c    | 30    | NA
+	`)
+}
+
+func delta() *dt.Frame {
+	return dttest.Frame(`
+		id | price | extra | name
+		3  | NA    | x     | cc
+		2  | 21    | y     | NA
+		4  | 40    | z     | d
+		2  | 22    | y     | bb
+	`)
+}
+
+func TestUpdate(t *testing.T) {
+	frame := master()
+	original := frame.Copy(false)
+	got := frame.Copy(false).Update(delta(), "id").Do()
+	// the last row of duplicate keys wins, and the lists not in frame a are ignored.
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		id | name | price | stock
+		1  | a    | 10    | 5
+		2  | bb   | 22    | 6
+		3  | cc   | NA    | NA
+	`), got)
+	dttest.AssertFrameEqual(t, original, frame)
+
+	got = master().Update(delta(), "id").SkipNA(true).Do()
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		id | name | price | stock
+		1  | a    | 10    | 5
+		2  | bb   | 22    | 6
+		3  | cc   | 30    | NA
+	`), got)
+
+	if _, err := master().Update(delta(), "stock").TryDo(); err == nil {
+		t.Error("a key not found is accepted")
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	frame := master()
+	original := frame.Copy(false)
+	got := frame.Copy(false).Upsert(delta(), "id").SkipNA(true).Do()
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		id | name | price | stock
+		1  | a    | 10    | 5
+		2  | bb   | 22    | 6
+		3  | cc   | 30    | NA
+		4  | d    | 40    | NA
+	`), got)
+	dttest.AssertFrameEqual(t, original, frame)
+}
+
+func TestCombineFirst(t *testing.T) {
+	got := master().CombineFirst(delta(), "id").Do()
+	dttest.AssertFrameEqual(t, dttest.Frame(`
+		id | name | price | stock
+		1  | a    | 10    | 5
+		2  | b    | 22    | 6
+		3  | c    | 30    | NA
+	`), got)
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Value is the value interface.
@@ -18,6 +19,19 @@ type Number float64
 // String is a string value.
 type String string
 
+// Null is the missing value, meaning "not provided".
+// A nil Value is taken as Null too.
+type Null struct{}
+
+// Int is an int64 value.
+type Int int64
+
+// Bool is a bool value.
+type Bool bool
+
+// Time is a time value.
+type Time time.Time
+
 // Number returns as a number value.
 func (a Number) Number() float64 {
 	return float64(a)
@@ -43,3 +57,51 @@ func (a Number) String() string {
 	}
 	return strconv.FormatFloat(float64(a), 'g', -1, 64)
 }
+
+// Number returns NaN.
+func (a Null) Number() float64 {
+	return math.NaN()
+}
+
+// String returns the empty string.
+func (a Null) String() string {
+	return ""
+}
+
+// Number returns as a number value.
+func (a Int) Number() float64 {
+	return float64(a)
+}
+
+// String returns as a string value.
+func (a Int) String() string {
+	return strconv.FormatInt(int64(a), 10)
+}
+
+// Number returns 1 for true and 0 for false.
+func (a Bool) Number() float64 {
+	if a {
+		return 1
+	}
+	return 0
+}
+
+// String returns as a string value.
+func (a Bool) String() string {
+	return strconv.FormatBool(bool(a))
+}
+
+// Number returns the seconds since the Unix epoch.
+func (a Time) Number() float64 {
+	t := time.Time(a)
+	return float64(t.Unix()) + float64(t.Nanosecond())/1e9
+}
+
+// String returns the date part only if the clock is zero, and RFC 3339 otherwise.
+func (a Time) String() string {
+	t := time.Time(a)
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339Nano)
+}